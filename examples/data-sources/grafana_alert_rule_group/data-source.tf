@@ -0,0 +1,40 @@
+resource "grafana_folder" "rule_folder" {
+  title = "My Alert Rule Folder"
+}
+
+resource "grafana_rule_group" "my_rule_group" {
+  name             = "My Rule Group"
+  folder_uid       = grafana_folder.rule_folder.uid
+  interval_seconds = 60
+  org_id           = 1
+
+  rule {
+    name      = "My Alert Rule"
+    condition = "B"
+    labels = {
+      team = "backend"
+    }
+    data {
+      ref_id         = "A"
+      datasource_uid = "PD8C576611E62080A"
+      model = jsonencode({
+        refId = "A"
+      })
+      relative_time_range {
+        from = 600
+        to   = 0
+      }
+    }
+    data {
+      ref_id = "B"
+      math {
+        expression = "$A > 3"
+      }
+    }
+  }
+}
+
+data "grafana_alert_rule_group" "from_name" {
+  name       = grafana_rule_group.my_rule_group.name
+  folder_uid = grafana_rule_group.my_rule_group.folder_uid
+}
@@ -0,0 +1,26 @@
+resource "grafana_data_source" "prometheus" {
+  type = "prometheus"
+  name = "Staging Prometheus"
+  uid  = "export-prometheus"
+  url  = "http://prometheus:9090"
+}
+
+resource "grafana_dashboard" "test" {
+  config_json = jsonencode({
+    uid   = "test-export-dashboard-uid"
+    title = "Production Overview"
+    panels = [{
+      id         = 1
+      type       = "timeseries"
+      title      = "Requests"
+      datasource = { type = "prometheus", uid = grafana_data_source.prometheus.uid }
+      gridPos    = { x = 0, y = 0, w = 12, h = 8 }
+      targets    = [{ refId = "A", expr = "sum(rate(http_requests_total[5m]))" }]
+    }]
+  })
+}
+
+data "grafana_dashboard" "exported" {
+  uid                = grafana_dashboard.test.uid
+  export_for_sharing = true
+}
@@ -0,0 +1,86 @@
+data "grafana_dashboard_json" "overview" {
+  title   = "Service Overview"
+  uid     = "service-overview"
+  tags    = ["terraform"]
+  refresh = "1m"
+
+  variable {
+    name            = "job"
+    type            = "query"
+    label           = "Job"
+    datasource_uid  = "prometheus"
+    datasource_type = "prometheus"
+    query           = "label_values(up, job)"
+    include_all     = true
+  }
+
+  annotation {
+    name            = "Deployments"
+    datasource_uid  = "prometheus"
+    datasource_type = "prometheus"
+    expr            = "changes(build_info{job=~\"$job\"}[1m]) > 0"
+  }
+
+  link {
+    title = "Runbook"
+    url   = "https://example.com/runbook"
+  }
+
+  panel {
+    title           = "Up"
+    type            = "stat"
+    datasource_uid  = "prometheus"
+    datasource_type = "prometheus"
+
+    target {
+      expr = "sum(up{job=~\"$job\"})"
+    }
+  }
+
+  row {
+    title = "Requests"
+
+    panel {
+      title           = "Request rate"
+      type            = "timeseries"
+      datasource_uid  = "prometheus"
+      datasource_type = "prometheus"
+
+      grid_pos {
+        x = 0
+        y = 9
+        w = 24
+        h = 8
+      }
+
+      target {
+        expr          = "sum by (code) (rate(http_requests_total{job=~\"$job\"}[$__rate_interval]))"
+        legend_format = "{{code}}"
+      }
+
+      field_config {
+        unit = "reqps"
+        threshold {
+          color = "green"
+        }
+        threshold {
+          color = "red"
+          value = "100"
+        }
+      }
+
+      override {
+        matcher_id      = "byName"
+        matcher_options = "500"
+        property {
+          id         = "color"
+          value_json = jsonencode({ mode = "fixed", fixedColor = "red" })
+        }
+      }
+    }
+  }
+}
+
+resource "grafana_dashboard" "overview" {
+  config_json = data.grafana_dashboard_json.overview.config_json
+}
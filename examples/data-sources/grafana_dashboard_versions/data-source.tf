@@ -0,0 +1,11 @@
+resource "grafana_dashboard" "test" {
+  config_json = jsonencode({
+    uid   = "versions-ds-dashboard"
+    title = "Production Overview"
+  })
+  message = "Initial version"
+}
+
+data "grafana_dashboard_versions" "test" {
+  dashboard_uid = grafana_dashboard.test.uid
+}
@@ -7,6 +7,7 @@ resource "grafana_dashboard" "data_source_dashboards1" {
   folder = grafana_folder.data_source_dashboards.id
   config_json = jsonencode({
     id            = 23456
+    uid           = "data-source-dashboards-1"
     title         = "data_source_dashboards 1"
     tags          = ["data_source_dashboards"]
     timezone      = "browser"
@@ -27,6 +28,21 @@ data "grafana_dashboards" "folder_ids_tags" {
   tags       = jsondecode(grafana_dashboard.data_source_dashboards1.config_json)["tags"]
 }
 
+data "grafana_dashboards" "folder_uids" {
+  folder_uids = [grafana_folder.data_source_dashboards.uid]
+  depends_on  = [grafana_dashboard.data_source_dashboards1]
+}
+
+// search by title, or get specific dashboards
+data "grafana_dashboards" "query" {
+  query      = "data_source_dashboards 1"
+  depends_on = [grafana_dashboard.data_source_dashboards1]
+}
+
+data "grafana_dashboards" "dashboard_uids" {
+  dashboard_uids = [grafana_dashboard.data_source_dashboards1.uid]
+}
+
 resource "grafana_dashboard" "data_source_dashboards2" {
   folder = 0 // General folder
   config_json = jsonencode({
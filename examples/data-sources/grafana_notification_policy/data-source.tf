@@ -0,0 +1,39 @@
+resource "grafana_contact_point" "a_contact_point" {
+  name = "A Contact Point"
+
+  email {
+    addresses = ["one@company.org", "two@company.org"]
+  }
+}
+
+resource "grafana_mute_timing" "a_mute_timing" {
+  name = "Some Mute Timing"
+
+  intervals {
+    weekdays = ["saturday", "sunday"]
+  }
+}
+
+resource "grafana_notification_policy" "my_notification_policy" {
+  group_by      = ["..."]
+  contact_point = grafana_contact_point.a_contact_point.name
+
+  policy {
+    matcher {
+      label = "team"
+      match = "="
+      value = "backend"
+    }
+    contact_point = grafana_contact_point.a_contact_point.name
+    group_by      = ["alertname"]
+    mute_timings  = [grafana_mute_timing.a_mute_timing.name]
+  }
+}
+
+data "grafana_notification_policy" "current" {
+  depends_on = [grafana_notification_policy.my_notification_policy]
+}
+
+output "alertmanager_yaml" {
+  value = data.grafana_notification_policy.current.alertmanager_yaml
+}
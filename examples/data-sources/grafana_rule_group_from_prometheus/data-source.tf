@@ -0,0 +1,67 @@
+resource "grafana_folder" "migrated" {
+  title = "Migrated Prometheus rules"
+}
+
+data "grafana_rule_group_from_prometheus" "node" {
+  datasource_uid        = "prometheus"
+  prometheus_rules_yaml = <<-EOT
+    groups:
+      - name: node
+        interval: 2m
+        rules:
+          - alert: HighCPU
+            expr: avg by (instance) (rate(node_cpu_seconds_total{mode!="idle"}[5m])) > 0.9
+            for: 10m
+            labels:
+              severity: warning
+            annotations:
+              summary: "CPU usage is high on {{ $labels.instance }}"
+          - alert: InstanceDown
+            expr: up{job="node"} == 0
+  EOT
+}
+
+resource "grafana_rule_group" "migrated" {
+  for_each = { for group in data.grafana_rule_group_from_prometheus.node.groups : group.name => group }
+
+  name             = each.value.name
+  folder_uid       = grafana_folder.migrated.uid
+  interval_seconds = each.value.interval_seconds
+
+  dynamic "rule" {
+    for_each = each.value.rule
+    content {
+      name        = rule.value.name
+      labels      = rule.value.labels
+      annotations = rule.value.annotations
+
+      # The alerting attributes can't be set on recording rules
+      condition      = length(rule.value.record) == 0 ? rule.value.condition : null
+      for            = length(rule.value.record) == 0 ? rule.value.for : null
+      no_data_state  = length(rule.value.record) == 0 ? rule.value.no_data_state : null
+      exec_err_state = length(rule.value.record) == 0 ? rule.value.exec_err_state : null
+
+      dynamic "record" {
+        for_each = rule.value.record
+        content {
+          metric = record.value.metric
+          from   = record.value.from
+        }
+      }
+
+      dynamic "data" {
+        for_each = rule.value.data
+        content {
+          ref_id         = data.value.ref_id
+          datasource_uid = data.value.datasource_uid
+          query_type     = data.value.query_type
+          model          = data.value.model
+          relative_time_range {
+            from = data.value.relative_time_range[0].from
+            to   = data.value.relative_time_range[0].to
+          }
+        }
+      }
+    }
+  }
+}
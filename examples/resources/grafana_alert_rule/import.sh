@@ -0,0 +1 @@
+terraform import grafana_alert_rule.alert_rule_name {{alert_rule_uid}}
@@ -0,0 +1,54 @@
+resource "grafana_folder" "rule_folder" {
+  title = "My Alert Rule Folder"
+}
+
+resource "grafana_alert_rule" "high_cpu" {
+  name           = "High CPU"
+  folder_uid     = grafana_folder.rule_folder.uid
+  rule_group     = "Shared Rule Group"
+  for            = "2m"
+  condition      = "B"
+  no_data_state  = "NoData"
+  exec_err_state = "Alerting"
+  annotations = {
+    "summary" = "CPU usage is above 80%"
+  }
+  labels = {
+    "team" = "infra"
+  }
+  data {
+    ref_id = "A"
+    relative_time_range {
+      from = 600
+      to   = 0
+    }
+    datasource_uid = "PD8C576611E62080A"
+    model = jsonencode({
+      hide          = false
+      intervalMs    = 1000
+      maxDataPoints = 43200
+      refId         = "A"
+    })
+  }
+  data {
+    ref_id = "B"
+    relative_time_range {
+      from = 0
+      to   = 0
+    }
+    datasource_uid = "-100"
+    model = jsonencode({
+      conditions = [{
+        evaluator = { params = [80], type = "gt" }
+        operator  = { type = "and" }
+        query     = { params = ["A"] }
+        reducer   = { params = [], type = "last" }
+        type      = "query"
+      }]
+      datasource = { type = "__expr__", uid = "-100" }
+      hide       = false
+      refId      = "B"
+      type       = "classic_conditions"
+    })
+  }
+}
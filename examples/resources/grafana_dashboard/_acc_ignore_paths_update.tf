@@ -0,0 +1,12 @@
+resource "grafana_dashboard" "test" {
+  config_json = jsonencode({
+    title   = "Ignored Paths Updated"
+    uid     = "ignore-paths"
+    refresh = "1m"
+    time = {
+      from = "now-6h"
+      to   = "now"
+    }
+  })
+  ignore_paths = ["/refresh", "/time"]
+}
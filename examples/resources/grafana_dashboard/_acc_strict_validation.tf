@@ -0,0 +1,15 @@
+resource "grafana_dashboard" "test" {
+  config_json = jsonencode({
+    title = "Strict Validation"
+    uid   = "strict-validation"
+    panels = [
+      {
+        title      = "Unknown data source"
+        type       = "timeseries"
+        datasource = { uid = "does-not-exist" }
+        gridPos    = { x = 0, y = 0, w = 12, h = 8 }
+      },
+    ]
+  })
+  strict_validation = true
+}
@@ -0,0 +1,17 @@
+resource "grafana_data_source" "prometheus" {
+  type = "prometheus"
+  name = "prometheus-node-exporter"
+  uid  = "prometheus-node-exporter"
+  url  = "http://prometheus:9090"
+}
+
+// Node Exporter Full, https://grafana.com/grafana/dashboards/1860
+resource "grafana_dashboard_gnet" "node_exporter" {
+  gnet_id       = 1860
+  gnet_revision = 30
+  uid           = "node-exporter-full"
+
+  inputs = {
+    DS_PROMETHEUS = grafana_data_source.prometheus.uid
+  }
+}
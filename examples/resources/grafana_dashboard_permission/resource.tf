@@ -3,15 +3,25 @@ resource "grafana_team" "team" {
 }
 
 resource "grafana_user" "user" {
-  email = "user.name@example.com"
+  email    = "user.name@example.com"
+  login    = "user.name"
+  password = "my-password"
+}
+
+resource "grafana_service_account" "sa" {
+  name = "my-service-account"
+  role = "Viewer"
 }
 
 resource "grafana_dashboard" "metrics" {
-  config_json = file("grafana-dashboard.json")
+  config_json = jsonencode({
+    "title" : "My Dashboard",
+    "uid" : "my-dashboard-uid"
+  })
 }
 
 resource "grafana_dashboard_permission" "collectionPermission" {
-  dashboard_id = grafana_dashboard.metrics.dashboard_id
+  dashboard_uid = grafana_dashboard.metrics.uid
   permissions {
     role       = "Editor"
     permission = "Edit"
@@ -24,4 +34,8 @@ resource "grafana_dashboard_permission" "collectionPermission" {
     user_id    = grafana_user.user.id
     permission = "Admin"
   }
+  permissions {
+    service_account_id = grafana_service_account.sa.id
+    permission         = "View"
+  }
 }
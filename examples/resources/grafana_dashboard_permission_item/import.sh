@@ -0,0 +1 @@
+terraform import grafana_dashboard_permission_item.item_name {{dashboard_uid}}:{{type (user, team, service_account or role)}}:{{identifier}}
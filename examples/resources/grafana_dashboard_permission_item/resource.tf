@@ -0,0 +1,45 @@
+resource "grafana_team" "team" {
+  name = "Team Name"
+}
+
+resource "grafana_user" "user" {
+  email    = "user.name@example.com"
+  login    = "user.name"
+  password = "my-password"
+}
+
+resource "grafana_service_account" "sa" {
+  name = "my-service-account"
+  role = "Viewer"
+}
+
+resource "grafana_dashboard" "metrics" {
+  config_json = jsonencode({
+    "title" : "My Dashboard",
+    "uid" : "my-dashboard-uid"
+  })
+}
+
+resource "grafana_dashboard_permission_item" "on_role" {
+  dashboard_uid = grafana_dashboard.metrics.uid
+  role          = "Viewer"
+  permission    = "Edit"
+}
+
+resource "grafana_dashboard_permission_item" "on_team" {
+  dashboard_uid = grafana_dashboard.metrics.uid
+  team_id       = grafana_team.team.id
+  permission    = "View"
+}
+
+resource "grafana_dashboard_permission_item" "on_user" {
+  dashboard_uid = grafana_dashboard.metrics.uid
+  user_id       = grafana_user.user.id
+  permission    = "Admin"
+}
+
+resource "grafana_dashboard_permission_item" "on_service_account" {
+  dashboard_uid      = grafana_dashboard.metrics.uid
+  service_account_id = grafana_service_account.sa.id
+  permission         = "View"
+}
@@ -0,0 +1 @@
+terraform import grafana_dashboard_public.public_dashboard_name {{dashboard_uid}}:{{public_dashboard_uid}}
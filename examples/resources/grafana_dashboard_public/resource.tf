@@ -0,0 +1,14 @@
+resource "grafana_dashboard" "status" {
+  config_json = jsonencode({
+    uid   = "public-status"
+    title = "Status"
+  })
+}
+
+resource "grafana_dashboard_public" "status" {
+  dashboard_uid          = grafana_dashboard.status.uid
+  is_enabled             = true
+  time_selection_enabled = true
+  annotations_enabled    = true
+  share                  = "public"
+}
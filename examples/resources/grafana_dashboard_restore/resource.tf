@@ -0,0 +1,9 @@
+data "grafana_dashboard_versions" "production" {
+  dashboard_uid = "production-overview"
+}
+
+# Roll back to the version before the latest one
+resource "grafana_dashboard_restore" "production" {
+  dashboard_uid = "production-overview"
+  version       = data.grafana_dashboard_versions.production.versions[1].version
+}
@@ -0,0 +1,21 @@
+resource "grafana_folder" "team" {
+  title = "Team dashboards"
+}
+
+// Dashboards are usually loaded from a directory:
+//   dashboards = { for f in fileset("${path.module}/dashboards", "*.json") : trimsuffix(f, ".json") => file("${path.module}/dashboards/${f}") }
+resource "grafana_dashboard_set" "team" {
+  folder      = grafana_folder.team.id
+  parallelism = 5
+
+  dashboards = {
+    "team-overview" = jsonencode({
+      title = "Team Overview"
+      tags  = ["team"]
+    })
+    "team-latency" = jsonencode({
+      title = "Team Latency"
+      tags  = ["team"]
+    })
+  }
+}
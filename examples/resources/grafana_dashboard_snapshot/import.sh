@@ -0,0 +1 @@
+terraform import grafana_dashboard_snapshot.snapshot_name {{snapshot_key}}
@@ -0,0 +1,12 @@
+resource "grafana_dashboard" "metrics" {
+  config_json = jsonencode({
+    uid   = "snapshot-metrics"
+    title = "Production Overview"
+  })
+}
+
+resource "grafana_dashboard_snapshot" "metrics" {
+  config_json = grafana_dashboard.metrics.config_json
+  name        = "Production Overview snapshot"
+  expires     = 3600
+}
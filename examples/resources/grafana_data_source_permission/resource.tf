@@ -2,6 +2,17 @@ resource "grafana_team" "team" {
   name = "Team Name"
 }
 
+resource "grafana_user" "user" {
+  email    = "user.name@example.com"
+  login    = "user.name"
+  password = "my-password"
+}
+
+resource "grafana_service_account" "sa" {
+  name = "my-service-account"
+  role = "Viewer"
+}
+
 resource "grafana_data_source" "foo" {
   type = "cloudwatch"
   name = "cw-example"
@@ -18,13 +29,21 @@ resource "grafana_data_source" "foo" {
 }
 
 resource "grafana_data_source_permission" "fooPermissions" {
-  datasource_id = grafana_data_source.foo.id
+  datasource_uid = grafana_data_source.foo.uid
   permissions {
     team_id    = grafana_team.team.id
     permission = "Query"
   }
   permissions {
-    user_id    = 3 // 3 is the admin user in cloud. It can't be queried
-    permission = "Query"
+    user_id    = grafana_user.user.id
+    permission = "Edit"
+  }
+  permissions {
+    service_account_id = grafana_service_account.sa.id
+    permission         = "Query"
+  }
+  permissions {
+    built_in_role = "Viewer"
+    permission    = "Query"
   }
 }
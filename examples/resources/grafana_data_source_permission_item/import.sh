@@ -0,0 +1 @@
+terraform import grafana_data_source_permission_item.item_name {{datasource_uid}}:{{type (user, team, service_account or role)}}:{{identifier}}
@@ -0,0 +1,53 @@
+resource "grafana_team" "team" {
+  name = "Team Name"
+}
+
+resource "grafana_user" "user" {
+  email    = "user.name@example.com"
+  login    = "user.name"
+  password = "my-password"
+}
+
+resource "grafana_service_account" "sa" {
+  name = "my-service-account"
+  role = "Viewer"
+}
+
+resource "grafana_data_source" "foo" {
+  type = "cloudwatch"
+  name = "cw-example"
+
+  json_data {
+    default_region = "us-east-1"
+    auth_type      = "keys"
+  }
+
+  secure_json_data {
+    access_key = "123"
+    secret_key = "456"
+  }
+}
+
+resource "grafana_data_source_permission_item" "on_role" {
+  datasource_uid = grafana_data_source.foo.uid
+  role           = "Viewer"
+  permission     = "Query"
+}
+
+resource "grafana_data_source_permission_item" "on_team" {
+  datasource_uid = grafana_data_source.foo.uid
+  team_id        = grafana_team.team.id
+  permission     = "Edit"
+}
+
+resource "grafana_data_source_permission_item" "on_user" {
+  datasource_uid = grafana_data_source.foo.uid
+  user_id        = grafana_user.user.id
+  permission     = "Admin"
+}
+
+resource "grafana_data_source_permission_item" "on_service_account" {
+  datasource_uid     = grafana_data_source.foo.uid
+  service_account_id = grafana_service_account.sa.id
+  permission         = "Query"
+}
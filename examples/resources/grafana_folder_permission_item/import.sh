@@ -0,0 +1 @@
+terraform import grafana_folder_permission_item.item_name {{folder_uid}}:{{type (user, team, service_account or role)}}:{{identifier}}
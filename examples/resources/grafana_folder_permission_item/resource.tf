@@ -0,0 +1,42 @@
+resource "grafana_team" "team" {
+  name = "Team Name"
+}
+
+resource "grafana_user" "user" {
+  email    = "user.name@example.com"
+  login    = "user.name"
+  password = "my-password"
+}
+
+resource "grafana_service_account" "sa" {
+  name = "my-service-account"
+  role = "Viewer"
+}
+
+resource "grafana_folder" "collection" {
+  title = "Folder Title"
+}
+
+resource "grafana_folder_permission_item" "on_role" {
+  folder_uid = grafana_folder.collection.uid
+  role       = "Viewer"
+  permission = "Edit"
+}
+
+resource "grafana_folder_permission_item" "on_team" {
+  folder_uid = grafana_folder.collection.uid
+  team_id    = grafana_team.team.id
+  permission = "View"
+}
+
+resource "grafana_folder_permission_item" "on_user" {
+  folder_uid = grafana_folder.collection.uid
+  user_id    = grafana_user.user.id
+  permission = "Admin"
+}
+
+resource "grafana_folder_permission_item" "on_service_account" {
+  folder_uid         = grafana_folder.collection.uid
+  service_account_id = grafana_service_account.sa.id
+  permission         = "View"
+}
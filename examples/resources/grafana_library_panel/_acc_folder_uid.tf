@@ -0,0 +1,12 @@
+resource "grafana_folder" "test_folder" {
+  title = "Terraform Folder UID Test Folder"
+}
+
+resource "grafana_library_panel" "test_folder" {
+  name       = "test-folder-uid"
+  folder_uid = grafana_folder.test_folder.uid
+  model_json = jsonencode({
+    title   = "test-folder-uid",
+    version = 43,
+  })
+}
@@ -0,0 +1,34 @@
+resource "grafana_contact_point" "a_contact_point" {
+    name = "A Contact Point"
+
+    email {
+        addresses = ["one@company.org", "two@company.org"]
+        message = "{{ len .Alerts.Firing }} firing."
+    }
+}
+
+resource "grafana_notification_policy" "my_notification_policy" {
+    # The route section of an Alertmanager configuration, nested deeper than the `policy` blocks allow
+    policy_tree_yaml = <<-EOT
+    route:
+      receiver: ${grafana_contact_point.a_contact_point.name}
+      group_by: [alertname]
+      group_wait: 45s
+      routes:
+        - matchers: [team="backend"]
+          receiver: ${grafana_contact_point.a_contact_point.name}
+          routes:
+            - match:
+                env: prod
+              routes:
+                - match_re:
+                    service: api|web
+                  routes:
+                    - matchers: ['severity=~"critical|page"']
+                      repeat_interval: 60m
+                      routes:
+                        - matchers: [region="eu"]
+                          receiver: ${grafana_contact_point.a_contact_point.name}
+                          continue: true
+    EOT
+}
@@ -0,0 +1,2 @@
+# The ID is the matchers of the route, sorted by label.
+terraform import grafana_notification_policy_route.backend '{team="backend"}'
@@ -0,0 +1,28 @@
+resource "grafana_contact_point" "backend" {
+    name = "Backend Team"
+
+    email {
+        addresses = ["backend@company.org"]
+    }
+}
+
+resource "grafana_notification_policy_route" "backend" {
+    matcher {
+        label = "team"
+        match = "="
+        value = "backend"
+    }
+    contact_point = grafana_contact_point.backend.name
+    group_by = ["alertname"]
+    repeat_interval = "3h"
+
+    policy {
+        matcher {
+            label = "severity"
+            match = "="
+            value = "critical"
+        }
+        contact_point = grafana_contact_point.backend.name
+        group_by = ["..."]
+    }
+}
@@ -0,0 +1,99 @@
+resource "grafana_folder" "rule_folder" {
+    title = "My Expressions Rule Folder"
+}
+
+resource "grafana_rule_group" "my_expressions_group" {
+    name = "My Expressions Rule Group"
+    folder_uid = grafana_folder.rule_folder.uid
+    interval_seconds = 60
+    org_id = 1
+    rule {
+        name = "My Expressions Rule"
+        for = "2m"
+        condition = "D"
+        data {
+            ref_id = "A"
+            relative_time_range {
+                from = 600
+                to = 0
+            }
+            datasource_uid = "PD8C576611E62080A"
+            model = jsonencode({
+                hide = false
+                intervalMs = 1000
+                maxDataPoints = 43200
+                refId = "A"
+            })
+        }
+        data {
+            ref_id = "B"
+            resample {
+                expression = "A"
+                window = "10s"
+                downsampler = "mean"
+                upsampler = "fillna"
+            }
+        }
+        data {
+            ref_id = "C"
+            reduce {
+                expression = "B"
+                reducer = "last"
+                mode = "dropNN"
+            }
+        }
+        data {
+            ref_id = "D"
+            threshold {
+                expression = "C"
+                evaluator {
+                    type = "gt"
+                    params = [3]
+                }
+            }
+        }
+    }
+    rule {
+        name = "My Classic Condition Rule"
+        condition = "C"
+        data {
+            ref_id = "A"
+            relative_time_range {
+                from = 600
+                to = 0
+            }
+            datasource_uid = "PD8C576611E62080A"
+            model = jsonencode({
+                refId = "A"
+            })
+        }
+        data {
+            ref_id = "B"
+            math {
+                expression = "$A * 100"
+            }
+        }
+        data {
+            ref_id = "C"
+            classic_condition {
+                condition {
+                    query = "B"
+                    reducer = "avg"
+                    evaluator {
+                        type = "within_range"
+                        params = [10, 90]
+                    }
+                }
+                condition {
+                    query = "B"
+                    reducer = "max"
+                    operator = "or"
+                    evaluator {
+                        type = "gt"
+                        params = [95]
+                    }
+                }
+            }
+        }
+    }
+}
@@ -0,0 +1,45 @@
+resource "grafana_folder" "rule_folder" {
+    title = "My Recording Rule Folder"
+}
+
+resource "grafana_rule_group" "my_recording_group" {
+    name = "My Recording Rule Group"
+    folder_uid = grafana_folder.rule_folder.uid
+    interval_seconds = 60
+    org_id = 1
+    rule {
+        name = "My Recording Rule"
+        record {
+            metric = "my_recorded_metric"
+            from = "B"
+        }
+        data {
+            ref_id = "A"
+            relative_time_range {
+                from = 600
+                to = 0
+            }
+            datasource_uid = "PD8C576611E62080A"
+            model = jsonencode({
+                refId = "A"
+            })
+        }
+        data {
+            ref_id = "B"
+            reduce {
+                expression = "A"
+                reducer = "last"
+            }
+        }
+    }
+    rule {
+        name = "My Alert Rule"
+        condition = "A"
+        data {
+            ref_id = "A"
+            math {
+                expression = "1 > 0"
+            }
+        }
+    }
+}
@@ -0,0 +1 @@
+terraform import grafana_rule_group_interval.interval_name {{folder_uid}};{{rule_group_name}}
@@ -0,0 +1,42 @@
+resource "grafana_folder" "rule_folder" {
+  title = "My Alert Rule Folder"
+}
+
+resource "grafana_alert_rule" "team_a" {
+  name       = "Team A Rule"
+  folder_uid = grafana_folder.rule_folder.uid
+  rule_group = "Shared Rule Group"
+  condition  = "B"
+  data {
+    ref_id = "A"
+    relative_time_range {
+      from = 600
+      to   = 0
+    }
+    datasource_uid = "PD8C576611E62080A"
+    model = jsonencode({
+      refId = "A"
+    })
+  }
+  data {
+    ref_id = "B"
+    relative_time_range {
+      from = 0
+      to   = 0
+    }
+    datasource_uid = "-100"
+    model = jsonencode({
+      datasource = { type = "__expr__", uid = "-100" }
+      expression = "$A > 3"
+      refId      = "B"
+      type       = "math"
+    })
+  }
+}
+
+// The group is created with its first rule
+resource "grafana_rule_group_interval" "shared" {
+  folder_uid       = grafana_folder.rule_folder.uid
+  rule_group       = grafana_alert_rule.team_a.rule_group
+  interval_seconds = 120
+}
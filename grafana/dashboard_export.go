@@ -0,0 +1,252 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+)
+
+// This file ports the "Export for sharing externally" feature of the Grafana frontend (see DashboardExporter
+// in the Grafana repository). Data source references are replaced by `${DS_<NAME>}` inputs, constant variables
+// by `${VAR_<NAME>}` inputs, and the plugins the dashboard needs are listed in `__requires`.
+
+// dashboardExporter holds what Grafana knows about the instance the dashboard is exported from.
+type dashboardExporter struct {
+	datasources    []*gapi.DataSource
+	plugins        map[string]grafanaPlugin
+	grafanaVersion string
+
+	inputs   []interface{}
+	requires map[string]map[string]interface{}
+	// datasourceInputs are the names of the inputs created for data sources, by data source UID
+	datasourceInputs map[string]string
+}
+
+func newDashboardExporter(datasources []*gapi.DataSource, plugins []grafanaPlugin, grafanaVersion string) *dashboardExporter {
+	e := &dashboardExporter{
+		datasources:    datasources,
+		plugins:        map[string]grafanaPlugin{},
+		grafanaVersion: grafanaVersion,
+	}
+	for _, plugin := range plugins {
+		e.plugins[plugin.ID] = plugin
+	}
+	return e
+}
+
+// export returns a copy of the dashboard in the form shared on grafana.com.
+func (e *dashboardExporter) export(dashboard map[string]interface{}) (map[string]interface{}, error) {
+	// Work on a copy of the dashboard
+	dashboardBytes, err := json.Marshal(dashboard)
+	if err != nil {
+		return nil, err
+	}
+	var exported map[string]interface{}
+	if err := json.Unmarshal(dashboardBytes, &exported); err != nil {
+		return nil, err
+	}
+
+	e.inputs = []interface{}{}
+	e.datasourceInputs = map[string]string{}
+	e.requires = map[string]map[string]interface{}{
+		"grafana": {"type": "grafana", "id": "grafana", "name": "Grafana", "version": e.grafanaVersion},
+	}
+
+	forEachDashboardPanel(exported["panels"], func(panel map[string]interface{}) {
+		if err == nil {
+			err = e.exportPanel(panel)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	forEachDashboardVariable(exported, func(variable map[string]interface{}) {
+		if err != nil {
+			return
+		}
+		switch variable["type"] {
+		case "query":
+			err = e.templateizeDatasource(variable, nil)
+			variable["options"] = []interface{}{}
+			variable["current"] = map[string]interface{}{}
+			if refresh, _ := variable["refresh"].(float64); refresh <= 0 {
+				variable["refresh"] = 1
+			}
+		case "constant":
+			e.exportConstant(variable)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	annotations, _ := exported["annotations"].(map[string]interface{})
+	annotationList, _ := annotations["list"].([]interface{})
+	for _, a := range annotationList {
+		if annotation, ok := a.(map[string]interface{}); ok {
+			if err := e.templateizeDatasource(annotation, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Grafana sorts the requirements by plugin ID
+	keys := make([]string, 0, len(e.requires))
+	for key := range e.requires {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		iID, jID := e.requires[keys[i]]["id"].(string), e.requires[keys[j]]["id"].(string)
+		if iID != jID {
+			return iID < jID
+		}
+		return keys[i] < keys[j]
+	})
+	requires := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		requires = append(requires, e.requires[key])
+	}
+
+	exported["id"] = nil
+	exported["__inputs"] = e.inputs
+	exported["__elements"] = map[string]interface{}{}
+	exported["__requires"] = requires
+	return exported, nil
+}
+
+func (e *dashboardExporter) exportPanel(panel map[string]interface{}) error {
+	panelType, _ := panel["type"].(string)
+	if panelType == "row" {
+		return nil
+	}
+
+	if _, ok := panel["datasource"]; ok {
+		if err := e.templateizeDatasource(panel, nil); err != nil {
+			return err
+		}
+	}
+	targets, _ := panel["targets"].([]interface{})
+	for _, t := range targets {
+		if target, ok := t.(map[string]interface{}); ok {
+			if err := e.templateizeDatasource(target, panel["datasource"]); err != nil {
+				return err
+			}
+		}
+	}
+
+	if panelType != "" {
+		plugin, ok := e.plugins[panelType]
+		if !ok {
+			plugin = grafanaPlugin{ID: panelType, Name: panelType}
+		}
+		e.require("panel", panelType, plugin)
+	}
+	return nil
+}
+
+// require adds a plugin to `__requires`. Like Grafana, plugins without version require 1.0.0.
+func (e *dashboardExporter) require(pluginType, id string, plugin grafanaPlugin) {
+	version := plugin.Info.Version
+	if version == "" {
+		version = "1.0.0"
+	}
+	e.requires[pluginType+id] = map[string]interface{}{
+		"type":    pluginType,
+		"id":      id,
+		"name":    plugin.Name,
+		"version": version,
+	}
+}
+
+// exportConstant replaces the value of a constant variable by an input.
+func (e *dashboardExporter) exportConstant(variable map[string]interface{}) {
+	name, _ := variable["name"].(string)
+	inputName := "VAR_" + strings.ToUpper(name)
+	label, _ := variable["label"].(string)
+	if label == "" {
+		label = name
+	}
+	value, _ := variable["query"].(string)
+	e.inputs = append(e.inputs, map[string]interface{}{
+		"name":        inputName,
+		"type":        "constant",
+		"label":       label,
+		"value":       value,
+		"description": "",
+	})
+
+	placeholder := "${" + inputName + "}"
+	current := map[string]interface{}{"value": placeholder, "text": placeholder}
+	variable["query"] = placeholder
+	variable["current"] = current
+	variable["options"] = []interface{}{current}
+	variable["hide"] = 2
+}
+
+// templateizeDatasource replaces the data source of a panel, query, variable or annotation by an input.
+// Objects without data source use the fallback, references to variables and built-in data sources are kept.
+func (e *dashboardExporter) templateizeDatasource(obj map[string]interface{}, fallback interface{}) error {
+	ref, ok := obj["datasource"]
+	if !ok {
+		if fallback != nil {
+			obj["datasource"] = fallback
+		}
+		return nil
+	}
+
+	var uid, name string
+	switch r := ref.(type) {
+	case string:
+		name = r
+	case map[string]interface{}:
+		uid, _ = r["uid"].(string)
+	}
+	if strings.HasPrefix(uid, "$") || strings.HasPrefix(name, "$") || dashboardBuiltinDatasourceUIDs[uid] || dashboardBuiltinDatasourceUIDs[name] {
+		return nil
+	}
+
+	var datasource *gapi.DataSource
+	for _, ds := range e.datasources {
+		if (uid != "" && ds.UID == uid) || (name != "" && ds.Name == name) || (uid == "" && name == "" && ds.IsDefault) {
+			datasource = ds
+			break
+		}
+	}
+	if datasource == nil {
+		if uid == "" && name == "" {
+			// No default data source, there is nothing to replace
+			return nil
+		}
+		return fmt.Errorf("data source %q does not exist", uid+name)
+	}
+
+	inputName, ok := e.datasourceInputs[datasource.UID]
+	plugin, hasPlugin := e.plugins[datasource.Type]
+	if !hasPlugin {
+		plugin = grafanaPlugin{ID: datasource.Type, Name: datasource.Type}
+	}
+	if !ok {
+		inputName = "DS_" + strings.ToUpper(strings.ReplaceAll(datasource.Name, " ", "_"))
+		e.datasourceInputs[datasource.UID] = inputName
+		e.inputs = append(e.inputs, map[string]interface{}{
+			"name":        inputName,
+			"label":       datasource.Name,
+			"description": "",
+			"type":        "datasource",
+			"pluginId":    datasource.Type,
+			"pluginName":  plugin.Name,
+		})
+	}
+
+	e.require("datasource", datasource.Type, plugin)
+
+	obj["datasource"] = map[string]interface{}{
+		"type": datasource.Type,
+		"uid":  "${" + inputName + "}",
+	}
+	return nil
+}
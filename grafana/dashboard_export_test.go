@@ -0,0 +1,93 @@
+package grafana
+
+import (
+	"encoding/json"
+	"testing"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+)
+
+func TestDashboardExporter(t *testing.T) {
+	IsUnitTest(t)
+
+	datasources := []*gapi.DataSource{
+		{UID: "prom-uid", Name: "Staging Prometheus", Type: "prometheus", IsDefault: true},
+		{UID: "loki-uid", Name: "Loki", Type: "loki"},
+	}
+	plugins := []grafanaPlugin{
+		{ID: "prometheus", Name: "Prometheus", Type: "datasource"},
+		{ID: "timeseries", Name: "Time series", Type: "panel"},
+		{ID: "logs", Name: "Logs", Type: "panel"},
+	}
+	plugins[0].Info.Version = "1.0.0"
+
+	dashboard := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(`{
+		"id": 12,
+		"uid": "exported",
+		"title": "Exported",
+		"annotations": {"list": [{"builtIn": 1, "datasource": {"type": "grafana", "uid": "-- Grafana --"}}]},
+		"panels": [
+			{"id": 1, "type": "timeseries", "datasource": {"type": "prometheus", "uid": "prom-uid"}, "targets": [{"refId": "A"}]},
+			{"id": 2, "type": "row", "collapsed": true, "panels": [
+				{"id": 3, "type": "logs", "datasource": "Loki", "targets": [{"refId": "A", "datasource": {"uid": "$logs"}}]}
+			]},
+			{"id": 4, "type": "timeseries", "datasource": null}
+		],
+		"templating": {"list": [
+			{"name": "job", "type": "query", "datasource": {"uid": "prom-uid"}, "refresh": 0, "options": [{"text": "api"}]},
+			{"name": "env", "type": "constant", "query": "prod"}
+		]}
+	}`), &dashboard); err != nil {
+		t.Fatal(err)
+	}
+
+	exported, err := newDashboardExporter(datasources, plugins, "9.3.0").export(dashboard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{
+		"id": null,
+		"uid": "exported",
+		"title": "Exported",
+		"__inputs": [
+			{"name": "DS_STAGING_PROMETHEUS", "label": "Staging Prometheus", "description": "", "type": "datasource", "pluginId": "prometheus", "pluginName": "Prometheus"},
+			{"name": "DS_LOKI", "label": "Loki", "description": "", "type": "datasource", "pluginId": "loki", "pluginName": "loki"},
+			{"name": "VAR_ENV", "label": "env", "description": "", "type": "constant", "value": "prod"}
+		],
+		"__elements": {},
+		"__requires": [
+			{"type": "grafana", "id": "grafana", "name": "Grafana", "version": "9.3.0"},
+			{"type": "panel", "id": "logs", "name": "Logs", "version": "1.0.0"},
+			{"type": "datasource", "id": "loki", "name": "loki", "version": "1.0.0"},
+			{"type": "datasource", "id": "prometheus", "name": "Prometheus", "version": "1.0.0"},
+			{"type": "panel", "id": "timeseries", "name": "Time series", "version": "1.0.0"}
+		],
+		"annotations": {"list": [{"builtIn": 1, "datasource": {"type": "grafana", "uid": "-- Grafana --"}}]},
+		"panels": [
+			{"id": 1, "type": "timeseries", "datasource": {"type": "prometheus", "uid": "${DS_STAGING_PROMETHEUS}"}, "targets": [{"refId": "A", "datasource": {"type": "prometheus", "uid": "${DS_STAGING_PROMETHEUS}"}}]},
+			{"id": 2, "type": "row", "collapsed": true, "panels": [
+				{"id": 3, "type": "logs", "datasource": {"type": "loki", "uid": "${DS_LOKI}"}, "targets": [{"refId": "A", "datasource": {"uid": "$logs"}}]}
+			]},
+			{"id": 4, "type": "timeseries", "datasource": {"type": "prometheus", "uid": "${DS_STAGING_PROMETHEUS}"}}
+		],
+		"templating": {"list": [
+			{"name": "job", "type": "query", "datasource": {"type": "prometheus", "uid": "${DS_STAGING_PROMETHEUS}"}, "refresh": 1, "options": [], "current": {}},
+			{"name": "env", "type": "constant", "query": "${VAR_ENV}", "hide": 2, "current": {"text": "${VAR_ENV}", "value": "${VAR_ENV}"}, "options": [{"text": "${VAR_ENV}", "value": "${VAR_ENV}"}]}
+		]}
+	}`
+	// The raw output is compared, normalizing the dashboards would remove the `id`
+	var want map[string]interface{}
+	if err := json.Unmarshal([]byte(expected), &want); err != nil {
+		t.Fatal(err)
+	}
+	gotJSON, _ := json.Marshal(exported)
+	wantJSON, _ := json.Marshal(want)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("unexpected export:\ngot:  %s\nwant: %s", gotJSON, wantJSON)
+	}
+
+	if _, err := newDashboardExporter(nil, plugins, "9.3.0").export(dashboard); err == nil || err.Error() != `data source "prom-uid" does not exist` {
+		t.Errorf("expected an error for an unknown data source, got %v", err)
+	}
+}
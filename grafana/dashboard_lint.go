@@ -0,0 +1,233 @@
+package grafana
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const dashboardMaxUIDLength = 40
+
+var (
+	// dashboardVariableRegexp matches the `$var`, `${var}`, `${var:format}` and `[[var]]` variable syntaxes
+	dashboardVariableRegexp = regexp.MustCompile(`\$\{(\w+)(?::[^}]*)?\}|\$(\w+)|\[\[(\w+)(?::[^\]]*)?\]\]`)
+	// dashboardBuiltinVariables are the variables and macros that Grafana and its data sources define, besides the ones starting
+	// with `__` (https://grafana.com/docs/grafana/latest/dashboards/variables/add-template-variables/#global-variables).
+	// `$m`, `$measurement`, `$col` and `$tag_<name>` are the alias patterns of InfluxQL.
+	dashboardBuiltinVariables = map[string]bool{
+		"interval":    true,
+		"timeFilter":  true,
+		"m":           true,
+		"measurement": true,
+		"col":         true,
+	}
+	// dashboardBuiltinDatasourceUIDs are the data sources that exist in every Grafana instance
+	dashboardBuiltinDatasourceUIDs = map[string]bool{
+		"grafana":         true,
+		"-- Grafana --":   true,
+		"-- Mixed --":     true,
+		"-- Dashboard --": true,
+	}
+)
+
+// lintDashboardModel returns the problems found in a dashboard model.
+// Data sources are checked separately by lintDashboardDatasources, since that needs the Grafana API.
+func lintDashboardModel(dashboard map[string]interface{}) []string {
+	var problems []string
+
+	if title, _ := dashboard["title"].(string); strings.TrimSpace(title) == "" {
+		problems = append(problems, "the dashboard has no title")
+	}
+	if uid, _ := dashboard["uid"].(string); len(uid) > dashboardMaxUIDLength {
+		problems = append(problems, fmt.Sprintf("uid %q is longer than %d characters", uid, dashboardMaxUIDLength))
+	}
+
+	// Duplicate panel IDs
+	panelsByID := map[float64]int{}
+	forEachDashboardPanel(dashboard["panels"], func(panel map[string]interface{}) {
+		if id, ok := panel["id"].(float64); ok {
+			panelsByID[id]++
+		}
+	})
+	var duplicateIDs []float64
+	for id, count := range panelsByID {
+		if count > 1 {
+			duplicateIDs = append(duplicateIDs, id)
+		}
+	}
+	sort.Float64s(duplicateIDs)
+	for _, id := range duplicateIDs {
+		problems = append(problems, fmt.Sprintf("panel ID %v is used by %d panels", id, panelsByID[id]))
+	}
+
+	// Overlapping panels. The panels of collapsed rows are positioned as if the row was expanded,
+	// so they are checked among themselves.
+	problems = append(problems, lintDashboardPanelPositions(dashboard["panels"])...)
+	forEachDashboardPanel(dashboard["panels"], func(panel map[string]interface{}) {
+		if collapsed, _ := panel["collapsed"].(bool); collapsed {
+			problems = append(problems, lintDashboardPanelPositions(panel["panels"])...)
+		}
+	})
+
+	// Unknown variables
+	variables := map[string]bool{}
+	forEachDashboardVariable(dashboard, func(variable map[string]interface{}) {
+		if name, ok := variable["name"].(string); ok {
+			variables[name] = true
+		}
+	})
+	forEachDashboardPanel(dashboard["panels"], func(panel map[string]interface{}) {
+		unknown := map[string]bool{}
+		for _, s := range jsonStrings(panel["targets"]) {
+			for _, match := range dashboardVariableRegexp.FindAllStringSubmatch(s, -1) {
+				name := match[1] + match[2] + match[3]
+				// Built-in variables start with `__`, and `$1` style references are regex captures
+				if strings.HasPrefix(name, "__") || strings.HasPrefix(name, "tag_") || strings.Trim(name, "0123456789") == "" ||
+					dashboardBuiltinVariables[name] || variables[name] {
+					continue
+				}
+				unknown[name] = true
+			}
+		}
+		names := make([]string, 0, len(unknown))
+		for name := range unknown {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			problems = append(problems, fmt.Sprintf("%s references unknown variable %q in its queries", dashboardPanelName(panel), name))
+		}
+	})
+
+	return problems
+}
+
+func lintDashboardPanelPositions(panels interface{}) []string {
+	type placedPanel struct {
+		panel      map[string]interface{}
+		x, y, w, h float64
+	}
+
+	var problems []string
+	var placed []placedPanel
+	panelList, _ := panels.([]interface{})
+	for _, p := range panelList {
+		panel, _ := p.(map[string]interface{})
+		gridPos, ok := panel["gridPos"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		current := placedPanel{
+			panel: panel,
+			x:     dashboardGridPosValue(gridPos, "x"),
+			y:     dashboardGridPosValue(gridPos, "y"),
+			w:     dashboardGridPosValue(gridPos, "w"),
+			h:     dashboardGridPosValue(gridPos, "h"),
+		}
+		for _, other := range placed {
+			if current.x < other.x+other.w && other.x < current.x+current.w && current.y < other.y+other.h && other.y < current.y+current.h {
+				problems = append(problems, fmt.Sprintf("%s overlaps with %s", dashboardPanelName(current.panel), dashboardPanelName(other.panel)))
+			}
+		}
+		placed = append(placed, current)
+	}
+	return problems
+}
+
+// dashboardDatasourceUIDs returns the UIDs of the data sources used by the panels and queries of a dashboard.
+// Variables (`$datasource`) and built-in data sources are not returned.
+func dashboardDatasourceUIDs(dashboard map[string]interface{}) map[string][]string {
+	uids := map[string][]string{}
+	add := func(panel map[string]interface{}, datasource interface{}) {
+		ref, _ := datasource.(map[string]interface{})
+		uid, _ := ref["uid"].(string)
+		if uid == "" || strings.HasPrefix(uid, "$") || dashboardBuiltinDatasourceUIDs[uid] {
+			return
+		}
+		name := dashboardPanelName(panel)
+		for _, existing := range uids[uid] {
+			if existing == name {
+				return
+			}
+		}
+		uids[uid] = append(uids[uid], name)
+	}
+
+	forEachDashboardPanel(dashboard["panels"], func(panel map[string]interface{}) {
+		add(panel, panel["datasource"])
+		targets, _ := panel["targets"].([]interface{})
+		for _, t := range targets {
+			target, _ := t.(map[string]interface{})
+			add(panel, target["datasource"])
+		}
+	})
+	return uids
+}
+
+// lintDashboardDatasources returns the data sources used by a dashboard that neither exist in Grafana nor are planned in the same run.
+func lintDashboardDatasources(client *client, dashboard map[string]interface{}) ([]string, error) {
+	uids := dashboardDatasourceUIDs(dashboard)
+	if len(uids) == 0 {
+		return nil, nil
+	}
+
+	datasources, err := client.gapi.DataSources()
+	if err != nil {
+		return nil, err
+	}
+	for _, datasource := range datasources {
+		delete(uids, datasource.UID)
+	}
+	for uid := range client.plannedNamesOf(plannedDatasourceUIDs) {
+		delete(uids, uid)
+	}
+
+	missing := make([]string, 0, len(uids))
+	for uid := range uids {
+		missing = append(missing, uid)
+	}
+	sort.Strings(missing)
+
+	var problems []string
+	for _, uid := range missing {
+		problems = append(problems, fmt.Sprintf("%s uses data source %q, which does not exist", strings.Join(uids[uid], ", "), uid))
+	}
+	return problems, nil
+}
+
+func dashboardPanelName(panel map[string]interface{}) string {
+	if title, _ := panel["title"].(string); title != "" {
+		return fmt.Sprintf("panel %q", title)
+	}
+	if id, ok := panel["id"].(float64); ok {
+		return fmt.Sprintf("panel %v", id)
+	}
+	return "untitled panel"
+}
+
+// jsonStrings returns all the strings of a decoded JSON value.
+func jsonStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var result []string
+		for _, item := range v {
+			result = append(result, jsonStrings(item)...)
+		}
+		return result
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var result []string
+		for _, key := range keys {
+			result = append(result, jsonStrings(v[key])...)
+		}
+		return result
+	}
+	return nil
+}
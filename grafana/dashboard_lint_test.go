@@ -0,0 +1,153 @@
+package grafana
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+)
+
+func TestLintDashboardModel(t *testing.T) {
+	IsUnitTest(t)
+
+	for _, tc := range []struct {
+		name     string
+		given    string
+		expected []string
+	}{
+		{
+			name: "valid dashboard",
+			given: `{
+				"title": "test",
+				"templating": {"list": [{"name": "job"}]},
+				"panels": [
+					{"id": 1, "title": "a", "gridPos": {"x": 0, "y": 0, "w": 12, "h": 8}, "targets": [{"expr": "rate(up{job=\"$job\", env=\"${job:regex}\"}[$__rate_interval])"}]},
+					{"id": 2, "title": "b", "gridPos": {"x": 12, "y": 0, "w": 12, "h": 8}, "targets": [{"expr": "label_replace(up, \"a\", \"$1\", \"b\", \"(.*)\")"}]},
+					{"id": 3, "type": "row", "collapsed": true, "gridPos": {"x": 0, "y": 8, "w": 24, "h": 1}, "panels": [
+						{"id": 4, "title": "c", "gridPos": {"x": 0, "y": 9, "w": 24, "h": 8}}
+					]}
+				]
+			}`,
+		},
+		{
+			name:  "missing title and long uid",
+			given: `{"uid": "abcdefghijklmnopqrstuvwxyzabcdefghijklmno"}`,
+			expected: []string{
+				"the dashboard has no title",
+				`uid "abcdefghijklmnopqrstuvwxyzabcdefghijklmno" is longer than 40 characters`,
+			},
+		},
+		{
+			name: "duplicate IDs and overlaps",
+			given: `{
+				"title": "test",
+				"panels": [
+					{"id": 1, "title": "a", "gridPos": {"x": 0, "y": 0, "w": 12, "h": 8}},
+					{"id": 1, "title": "b", "gridPos": {"x": 6, "y": 4, "w": 12, "h": 8}},
+					{"id": 2, "type": "row", "collapsed": true, "gridPos": {"x": 0, "y": 12, "w": 24, "h": 1}, "panels": [
+						{"id": 2, "gridPos": {"x": 0, "y": 0, "w": 24, "h": 8}},
+						{"id": 3, "gridPos": {"x": 0, "y": 0, "w": 24, "h": 8}}
+					]}
+				]
+			}`,
+			expected: []string{
+				"panel ID 1 is used by 2 panels",
+				"panel ID 2 is used by 2 panels",
+				`panel "b" overlaps with panel "a"`,
+				"panel 3 overlaps with panel 2",
+			},
+		},
+		{
+			name: "unknown variables",
+			given: `{
+				"title": "test",
+				"templating": {"list": [{"name": "job"}]},
+				"panels": [{"title": "a", "targets": [{"expr": "up{job=\"$job\", env=\"$env\", region=\"[[region]]\"}"}, {"rawSql": "SELECT 1 WHERE x = ${env:sqlstring}"}]}]
+			}`,
+			expected: []string{
+				`panel "a" references unknown variable "env" in its queries`,
+				`panel "a" references unknown variable "region" in its queries`,
+			},
+		},
+		{
+			name: "built-in variables",
+			given: `{
+				"title": "test",
+				"panels": [{"title": "a", "targets": [
+					{"query": "SELECT mean(\"value\") FROM \"cpu\" WHERE $timeFilter GROUP BY time($interval), \"host\"", "alias": "$tag_host $col"},
+					{"expr": "sum(rate(up[$__rate_interval])) by (job)", "legendFormat": "$m $measurement"}
+				]}]
+			}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			model, err := unmarshalDashboardConfigJSON(tc.given)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := lintDashboardModel(model); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestDashboardDatasourceUIDs(t *testing.T) {
+	IsUnitTest(t)
+
+	model, err := unmarshalDashboardConfigJSON(`{
+		"panels": [
+			{"title": "a", "datasource": {"uid": "prom"}, "targets": [{"datasource": {"uid": "loki"}}, {"datasource": {"uid": "prom"}}]},
+			{"title": "b", "datasource": {"uid": "$datasource"}},
+			{"type": "row", "collapsed": true, "panels": [{"title": "c", "datasource": {"uid": "-- Mixed --"}, "targets": [{"datasource": {"uid": "prom"}}]}]}
+		]
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string][]string{
+		"prom": {`panel "a"`, `panel "c"`},
+		"loki": {`panel "a"`},
+	}
+	if got := dashboardDatasourceUIDs(model); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestLintDashboardDatasources(t *testing.T) {
+	IsUnitTest(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/datasources" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `[{"id": 1, "uid": "existing", "name": "Existing"}]`)
+	}))
+	defer server.Close()
+	gapiClient, err := gapi.New(server.URL, gapi.Config{Client: server.Client()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &client{gapi: gapiClient, plannedNames: map[string]map[string]bool{plannedDatasourceUIDs: {"planned": true}}}
+
+	model, err := unmarshalDashboardConfigJSON(`{"title": "test", "panels": [
+		{"title": "a", "datasource": {"uid": "existing"}},
+		{"title": "b", "datasource": {"uid": "planned"}},
+		{"title": "c", "datasource": {"uid": "missing"}}
+	]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	problems, err := lintDashboardDatasources(c, model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{`panel "c" uses data source "missing", which does not exist`}; !reflect.DeepEqual(problems, expected) {
+		t.Errorf("expected %q, got %q", expected, problems)
+	}
+}
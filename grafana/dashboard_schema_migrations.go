@@ -0,0 +1,362 @@
+package grafana
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// This file ports the dashboard schema migrations that the Grafana frontend applies when
+// a dashboard is loaded (see DashboardMigrator in the Grafana repository). Dashboards saved
+// from the UI are migrated, so applying the same migrations to the configured JSON lets
+// both be compared.
+// Migrations that depend on the Grafana instance, such as data source references, are not ported.
+
+const (
+	dashboardGridCellHeight   = 30
+	dashboardGridCellVMargin  = 8
+	dashboardMinPanelHeight   = dashboardGridCellHeight * 3
+	dashboardDefaultRowHeight = 250
+	dashboardDefaultPanelSpan = 4
+)
+
+type dashboardSchemaMigration struct {
+	// version is the schema version the migration upgrades dashboards to
+	version int
+	migrate func(dashboard map[string]interface{})
+}
+
+var dashboardSchemaMigrations = []dashboardSchemaMigration{
+	{version: 14, migrate: migrateDashboardSharedCrosshair},
+	{version: 16, migrate: migrateDashboardRowsToGridLayout},
+	{version: 17, migrate: migrateDashboardPanelMinSpan},
+	{version: 27, migrate: migrateDashboardConstantVariables},
+	{version: 29, migrate: migrateDashboardQueryVariables},
+	{version: 37, migrate: migrateDashboardHiddenLegends},
+}
+
+// dashboardSchemaMigrationVersions returns the schema versions that dashboards can be migrated to, such as `14, 16 and 37`.
+func dashboardSchemaMigrationVersions() string {
+	versions := make([]string, 0, len(dashboardSchemaMigrations))
+	for _, migration := range dashboardSchemaMigrations {
+		versions = append(versions, strconv.Itoa(migration.version))
+	}
+	return strings.Join(versions[:len(versions)-1], ", ") + " and " + versions[len(versions)-1]
+}
+
+// migrateDashboardModel applies the ported migrations for the versions above the `schemaVersion` of the dashboard, and tells
+// whether the dashboard is now at the latest ported version. `schemaVersion` is only updated when all the versions in between
+// are ported, otherwise it's left untouched, since the migrations of the other versions are missing from the dashboard.
+func migrateDashboardModel(dashboard map[string]interface{}) bool {
+	schemaVersion := 0
+	if v, ok := dashboard["schemaVersion"].(float64); ok {
+		schemaVersion = int(v)
+	}
+
+	complete := true
+	next := schemaVersion + 1
+	for _, migration := range dashboardSchemaMigrations {
+		if migration.version <= schemaVersion {
+			continue
+		}
+		migration.migrate(dashboard)
+		complete = complete && migration.version == next
+		next = migration.version + 1
+	}
+
+	if complete && next > schemaVersion+1 {
+		dashboard["schemaVersion"] = next - 1
+	}
+	return complete
+}
+
+// forEachDashboardPanel calls fn for each panel of the dashboard, including the ones nested in collapsed rows.
+func forEachDashboardPanel(panels interface{}, fn func(panel map[string]interface{})) {
+	panelList, _ := panels.([]interface{})
+	for _, p := range panelList {
+		if panel, ok := p.(map[string]interface{}); ok {
+			fn(panel)
+			forEachDashboardPanel(panel["panels"], fn)
+		}
+	}
+}
+
+func forEachDashboardVariable(dashboard map[string]interface{}, fn func(variable map[string]interface{})) {
+	templating, _ := dashboard["templating"].(map[string]interface{})
+	variables, _ := templating["list"].([]interface{})
+	for _, v := range variables {
+		if variable, ok := v.(map[string]interface{}); ok {
+			fn(variable)
+		}
+	}
+}
+
+// migrateDashboardSharedCrosshair replaces `sharedCrosshair` by `graphTooltip`.
+func migrateDashboardSharedCrosshair(dashboard map[string]interface{}) {
+	sharedCrosshair, ok := dashboard["sharedCrosshair"]
+	if !ok {
+		return
+	}
+	if shared, _ := sharedCrosshair.(bool); shared {
+		dashboard["graphTooltip"] = 1
+	} else {
+		dashboard["graphTooltip"] = 0
+	}
+	delete(dashboard, "sharedCrosshair")
+}
+
+// migrateDashboardRowsToGridLayout replaces `rows` by row panels and positions panels on the grid.
+func migrateDashboardRowsToGridLayout(dashboard map[string]interface{}) {
+	rows, ok := dashboard["rows"].([]interface{})
+	delete(dashboard, "rows")
+	if !ok || len(rows) == 0 {
+		return
+	}
+
+	panels, _ := dashboard["panels"].([]interface{})
+	showRows := false
+	for _, r := range rows {
+		row, _ := r.(map[string]interface{})
+		if collapse, _ := row["collapse"].(bool); collapse {
+			showRows = true
+		}
+		if showTitle, _ := row["showTitle"].(bool); showTitle {
+			showRows = true
+		}
+		if repeat, _ := row["repeat"].(string); repeat != "" {
+			showRows = true
+		}
+	}
+
+	widthFactor := dashboardGridWidth / 12
+	yPos := 0
+	for _, r := range rows {
+		row, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		rowGridHeight := dashboardGridHeight(row["height"], dashboardDefaultRowHeight)
+		collapsed, _ := row["collapse"].(bool)
+
+		var rowPanel map[string]interface{}
+		if showRows {
+			rowPanel = map[string]interface{}{
+				"type":      "row",
+				"title":     row["title"],
+				"collapsed": collapsed,
+				"panels":    []interface{}{},
+				"gridPos":   dashboardJSONGridPos(0, yPos, dashboardGridWidth, rowGridHeight),
+			}
+			if repeat, ok := row["repeat"]; ok {
+				rowPanel["repeat"] = repeat
+			}
+			yPos++
+		}
+
+		area := newDashboardRowArea(rowGridHeight, yPos)
+		rowPanels, _ := row["panels"].([]interface{})
+		for _, p := range rowPanels {
+			panel, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			span, _ := panel["span"].(float64)
+			if span == 0 {
+				span = dashboardDefaultPanelSpan
+			}
+			if minSpan, ok := panel["minSpan"].(float64); ok && minSpan != 0 {
+				panel["minSpan"] = math.Min(dashboardGridWidth, float64(widthFactor)*minSpan)
+			}
+			panelWidth := int(math.Floor(span)) * widthFactor
+			panelHeight := rowGridHeight
+			if height, ok := panel["height"]; ok {
+				panelHeight = dashboardGridHeight(height, dashboardDefaultRowHeight)
+			}
+
+			x, y := area.panelPosition(panelHeight, panelWidth)
+			yPos = area.yPos
+			gridPos := dashboardJSONGridPos(x, yPos+y, panelWidth, panelHeight)
+			panel["gridPos"] = gridPos
+			area.addPanel(x, yPos+y, panelWidth, panelHeight)
+			delete(panel, "span")
+
+			if rowPanel != nil && collapsed {
+				rowPanel["panels"] = append(rowPanel["panels"].([]interface{}), panel)
+			} else {
+				panels = append(panels, panel)
+			}
+		}
+
+		if rowPanel != nil {
+			panels = append(panels, rowPanel)
+		}
+		if rowPanel == nil || !collapsed {
+			yPos += rowGridHeight
+		}
+	}
+
+	// Grafana sorts panels by position when loading a dashboard
+	sort.SliceStable(panels, func(i, j int) bool {
+		iPos, _ := panels[i].(map[string]interface{})["gridPos"].(map[string]interface{})
+		jPos, _ := panels[j].(map[string]interface{})["gridPos"].(map[string]interface{})
+		iY, jY := dashboardGridPosValue(iPos, "y"), dashboardGridPosValue(jPos, "y")
+		if iY != jY {
+			return iY < jY
+		}
+		return dashboardGridPosValue(iPos, "x") < dashboardGridPosValue(jPos, "x")
+	})
+	dashboard["panels"] = panels
+}
+
+// dashboardGridHeight converts a height in pixels (`250` or `"250px"`) to grid units.
+func dashboardGridHeight(height interface{}, defaultHeight int) int {
+	pixels := defaultHeight
+	switch h := height.(type) {
+	case float64:
+		pixels = int(h)
+	case string:
+		if parsed, err := strconv.Atoi(strings.TrimSuffix(h, "px")); err == nil {
+			pixels = parsed
+		}
+	}
+	if pixels < dashboardMinPanelHeight {
+		pixels = dashboardMinPanelHeight
+	}
+	return int(math.Ceil(float64(pixels) / (dashboardGridCellHeight + dashboardGridCellVMargin)))
+}
+
+func dashboardGridPosValue(gridPos map[string]interface{}, key string) float64 {
+	switch v := gridPos[key].(type) {
+	case int:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
+
+// dashboardRowArea places the panels of a legacy row, the same way Grafana does when migrating rows.
+type dashboardRowArea struct {
+	// area holds the height used in each column of the row
+	area   []int
+	yPos   int
+	height int
+}
+
+func newDashboardRowArea(height, yPos int) *dashboardRowArea {
+	return &dashboardRowArea{area: make([]int, dashboardGridWidth), yPos: yPos, height: height}
+}
+
+func (a *dashboardRowArea) addPanel(x, y, w, h int) {
+	for i := x; i < x+w && i < len(a.area); i++ {
+		if used := y + h - a.yPos; used > a.area[i] {
+			a.area[i] = used
+		}
+	}
+}
+
+func (a *dashboardRowArea) panelPosition(panelHeight, panelWidth int) (int, int) {
+	for attempt := 0; attempt < 2; attempt++ {
+		startPlace, endPlace := -1, -1
+		for i := len(a.area) - 1; i >= 0; i-- {
+			if a.height-a.area[i] <= 0 {
+				break
+			}
+			if endPlace == -1 {
+				endPlace = i
+			} else if i < len(a.area)-1 && a.area[i] <= a.area[i+1] {
+				startPlace = i
+			} else {
+				break
+			}
+		}
+
+		if startPlace != -1 && endPlace != -1 && endPlace-startPlace >= panelWidth-1 {
+			y := 0
+			for _, used := range a.area[startPlace:] {
+				if used > y {
+					y = used
+				}
+			}
+			return startPlace, y
+		}
+
+		// Wrap to the next line
+		a.yPos += a.height
+		for i := range a.area {
+			a.area[i] = 0
+		}
+	}
+	return 0, 0
+}
+
+// migrateDashboardPanelMinSpan replaces the `minSpan` of panels by `maxPerRow`.
+func migrateDashboardPanelMinSpan(dashboard map[string]interface{}) {
+	factors := []float64{1, 2, 3, 4, 6, 8, 12, 24}
+	forEachDashboardPanel(dashboard["panels"], func(panel map[string]interface{}) {
+		if minSpan, ok := panel["minSpan"].(float64); ok && minSpan != 0 {
+			limit := dashboardGridWidth / minSpan
+			// Best match among the factors of the grid width
+			maxPerRow := factors[len(factors)-1]
+			for i, factor := range factors {
+				if factor > limit {
+					if i > 0 {
+						maxPerRow = factors[i-1]
+					}
+					break
+				}
+			}
+			panel["maxPerRow"] = maxPerRow
+		}
+		delete(panel, "minSpan")
+	})
+}
+
+// migrateDashboardConstantVariables turns visible constant variables into text boxes, and sets the current value of constants.
+func migrateDashboardConstantVariables(dashboard map[string]interface{}) {
+	forEachDashboardVariable(dashboard, func(variable map[string]interface{}) {
+		if variable["type"] != "constant" {
+			return
+		}
+		if hide, _ := variable["hide"].(float64); hide == 0 || hide == 1 {
+			variable["type"] = "textbox"
+		}
+		query, _ := variable["query"].(string)
+		current := map[string]interface{}{"selected": true, "text": query, "value": query}
+		variable["current"] = current
+		variable["options"] = []interface{}{current}
+	})
+}
+
+// migrateDashboardQueryVariables makes query variables refresh on load at least, and removes their saved options.
+func migrateDashboardQueryVariables(dashboard map[string]interface{}) {
+	forEachDashboardVariable(dashboard, func(variable map[string]interface{}) {
+		if variable["type"] != "query" {
+			return
+		}
+		if refresh, _ := variable["refresh"].(float64); refresh != 1 && refresh != 2 {
+			variable["refresh"] = 1
+		}
+		if options, _ := variable["options"].([]interface{}); len(options) > 0 {
+			variable["options"] = []interface{}{}
+		}
+	})
+}
+
+// migrateDashboardHiddenLegends replaces the `hidden` legend display mode by `showLegend`.
+func migrateDashboardHiddenLegends(dashboard map[string]interface{}) {
+	forEachDashboardPanel(dashboard["panels"], func(panel map[string]interface{}) {
+		options, _ := panel["options"].(map[string]interface{})
+		legend, ok := options["legend"].(map[string]interface{})
+		if !ok {
+			return
+		}
+		if showLegend, ok := legend["showLegend"].(bool); legend["displayMode"] == "hidden" || (ok && !showLegend) {
+			legend["displayMode"] = "list"
+			legend["showLegend"] = false
+		} else {
+			legend["showLegend"] = true
+		}
+	})
+}
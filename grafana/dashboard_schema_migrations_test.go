@@ -0,0 +1,101 @@
+package grafana
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMigrateDashboardModel(t *testing.T) {
+	IsUnitTest(t)
+
+	for _, tc := range []struct {
+		name     string
+		given    string
+		expected string
+		complete bool
+	}{
+		{
+			name:     "current schema version is not migrated",
+			given:    `{"schemaVersion":37,"sharedCrosshair":true}`,
+			expected: `{"schemaVersion":37,"sharedCrosshair":true}`,
+			complete: true,
+		},
+		{
+			name:     "shared crosshair",
+			given:    `{"schemaVersion":13,"sharedCrosshair":true}`,
+			expected: `{"graphTooltip":1,"schemaVersion":13}`,
+		},
+		{
+			name: "rows to grid layout",
+			given: `{"schemaVersion":15,"rows":[
+				{"title":"first","showTitle":true,"height":"250px","panels":[{"title":"a","span":6},{"title":"b","span":6},{"title":"c","span":12,"height":100}]},
+				{"title":"second","collapse":true,"height":"300px","panels":[{"title":"d"}]}
+			]}`,
+			expected: `{"panels":[
+				{"collapsed":false,"gridPos":{"h":7,"w":24,"x":0,"y":0},"panels":[],"title":"first","type":"row"},
+				{"gridPos":{"h":7,"w":12,"x":0,"y":1},"title":"a"},
+				{"gridPos":{"h":7,"w":12,"x":12,"y":1},"title":"b"},
+				{"gridPos":{"h":3,"w":24,"x":0,"y":8},"height":100,"title":"c"},
+				{"collapsed":true,"gridPos":{"h":8,"w":24,"x":0,"y":15},"panels":[{"gridPos":{"h":8,"w":8,"x":0,"y":16},"title":"d"}],"title":"second","type":"row"}
+			],"schemaVersion":15}`,
+		},
+		{
+			name:     "min span",
+			given:    `{"schemaVersion":16,"panels":[{"minSpan":8},{"type":"row","panels":[{"minSpan":5}]}]}`,
+			expected: `{"panels":[{"maxPerRow":3},{"panels":[{"maxPerRow":4}],"type":"row"}],"schemaVersion":16}`,
+		},
+		{
+			name: "variables",
+			given: `{"schemaVersion":26,"templating":{"list":[
+				{"type":"constant","query":"a","hide":0},
+				{"type":"constant","query":"b","hide":2},
+				{"type":"query","refresh":0,"options":[{"text":"x","value":"x"}]}
+			]}}`,
+			expected: `{"schemaVersion":26,"templating":{"list":[
+				{"current":{"selected":true,"text":"a","value":"a"},"hide":0,"options":[{"selected":true,"text":"a","value":"a"}],"query":"a","type":"textbox"},
+				{"current":{"selected":true,"text":"b","value":"b"},"hide":2,"options":[{"selected":true,"text":"b","value":"b"}],"query":"b","type":"constant"},
+				{"options":[],"refresh":1,"type":"query"}
+			]}}`,
+		},
+		{
+			name:     "hidden legends",
+			given:    `{"schemaVersion":36,"panels":[{"options":{"legend":{"displayMode":"hidden"}}},{"options":{"legend":{"displayMode":"table"}}}]}`,
+			expected: `{"panels":[{"options":{"legend":{"displayMode":"list","showLegend":false}}},{"options":{"legend":{"displayMode":"table","showLegend":true}}}],"schemaVersion":37}`,
+			complete: true,
+		},
+		{
+			name:     "missing schema version",
+			given:    `{"sharedCrosshair":false}`,
+			expected: `{"graphTooltip":0}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			model, err := unmarshalDashboardConfigJSON(tc.given)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if complete := migrateDashboardModel(model); complete != tc.complete {
+				t.Errorf("expected the migration to be complete: %t, got %t", tc.complete, complete)
+			}
+			got, _ := json.Marshal(model)
+
+			expected, err := unmarshalDashboardConfigJSON(tc.expected)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, _ := json.Marshal(expected)
+
+			if string(got) != string(want) {
+				t.Errorf("expected %s, got %s", want, got)
+			}
+		})
+	}
+}
+
+func TestDashboardSchemaMigrationVersions(t *testing.T) {
+	IsUnitTest(t)
+
+	if versions := dashboardSchemaMigrationVersions(); versions != "14, 16, 17, 27, 29 and 37" {
+		t.Errorf("unexpected versions: %s", versions)
+	}
+}
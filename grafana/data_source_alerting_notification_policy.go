@@ -0,0 +1,131 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+func DatasourceNotificationPolicy() *schema.Resource {
+	policySchema := computedSchemaForDatasource(ResourceNotificationPolicy().Schema)
+	delete(policySchema, "disable_provenance")
+	delete(policySchema, "strict_validation")
+	for _, attr := range policyTreeAttributes {
+		delete(policySchema, attr)
+	}
+	policySchema["contact_point"].Description = "The default contact point, that all unmatched notifications are routed to."
+	policySchema["policy"].Description = fmt.Sprintf("Routing rules for specific label sets, up to a depth of %d. The entire tree is in `alertmanager_yaml`.", supportedPolicyTreeDepth)
+	policySchema["alertmanager_yaml"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+		Description: "The entire notification policy tree and the mute timings, in the format of the Alertmanager configuration. " +
+			"The tree is under the `route` key, with its matchers in the `matchers` format, and the mute timings are under the `mute_time_intervals` key.",
+	}
+
+	return &schema.Resource{
+		Description: `
+Data source for reading the notification policy tree of Grafana, including the policies that are not managed by Terraform.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/alerting/notifications/)
+* [HTTP API](https://grafana.com/docs/grafana/next/developers/http_api/alerting_provisioning/#notification-policies)
+
+This data source requires Grafana 9.1.0 or later.
+`,
+		ReadContext: dataSourceNotificationPolicyRead,
+		Schema:      policySchema,
+	}
+}
+
+func dataSourceNotificationPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	raw, err := client.notificationPolicyTree()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rawJSON, err := json.Marshal(raw)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var npt gapi.NotificationPolicyTree
+	if err := json.Unmarshal(rawJSON, &npt); err != nil {
+		return diag.FromErr(err)
+	}
+
+	tree, err := normalizePolicyRoute(raw)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	muteTimings, err := client.muteTimings()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	alertmanagerYAML, err := alertmanagerConfigYAML(tree, muteTimings)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(PolicySingletonID)
+	packNotifPolicy(npt, d)
+	d.Set("alertmanager_yaml", alertmanagerYAML)
+	return nil
+}
+
+// alertmanagerRoute is a route of the Alertmanager configuration. It's read from a normalized route of the Grafana API,
+// whose `object_matchers` are converted to `matchers`.
+type alertmanagerRoute struct {
+	Receiver            string              `json:"receiver,omitempty" yaml:"receiver,omitempty"`
+	GroupBy             []string            `json:"group_by,omitempty" yaml:"group_by,omitempty"`
+	Continue            bool                `json:"continue,omitempty" yaml:"continue,omitempty"`
+	ObjectMatchers      [][]string          `json:"object_matchers,omitempty" yaml:"-"`
+	Matchers            []string            `json:"-" yaml:"matchers,omitempty"`
+	MuteTimeIntervals   []string            `json:"mute_time_intervals,omitempty" yaml:"mute_time_intervals,omitempty"`
+	ActiveTimeIntervals []string            `json:"active_time_intervals,omitempty" yaml:"active_time_intervals,omitempty"`
+	GroupWait           string              `json:"group_wait,omitempty" yaml:"group_wait,omitempty"`
+	GroupInterval       string              `json:"group_interval,omitempty" yaml:"group_interval,omitempty"`
+	RepeatInterval      string              `json:"repeat_interval,omitempty" yaml:"repeat_interval,omitempty"`
+	Routes              []alertmanagerRoute `json:"routes,omitempty" yaml:"routes,omitempty"`
+}
+
+func (r *alertmanagerRoute) convertMatchers() {
+	for _, m := range r.ObjectMatchers {
+		if len(m) == 3 {
+			r.Matchers = append(r.Matchers, m[0]+m[1]+strconv.Quote(m[2]))
+		}
+	}
+	for i := range r.Routes {
+		r.Routes[i].convertMatchers()
+	}
+}
+
+// alertmanagerConfigYAML returns a normalized notification policy tree and the mute timings as an Alertmanager configuration.
+// The mute timings are sorted by name.
+func alertmanagerConfigYAML(tree map[string]interface{}, muteTimings []muteTiming) (string, error) {
+	treeJSON, err := json.Marshal(tree)
+	if err != nil {
+		return "", err
+	}
+	var config struct {
+		Route             alertmanagerRoute `yaml:"route"`
+		MuteTimeIntervals []muteTiming      `yaml:"mute_time_intervals,omitempty"`
+	}
+	if err := json.Unmarshal(treeJSON, &config.Route); err != nil {
+		return "", err
+	}
+	config.Route.convertMatchers()
+
+	config.MuteTimeIntervals = append(config.MuteTimeIntervals, muteTimings...)
+	sort.Slice(config.MuteTimeIntervals, func(i, j int) bool {
+		return config.MuteTimeIntervals[i].Name < config.MuteTimeIntervals[j].Name
+	})
+
+	document, err := yaml.Marshal(config)
+	return string(document), err
+}
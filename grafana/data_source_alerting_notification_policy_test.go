@@ -0,0 +1,97 @@
+package grafana
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceNotificationPolicy(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=9.1.0")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testNotifPolicyCheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "data-sources/grafana_notification_policy/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafana_notification_policy.current", "id", PolicySingletonID),
+					resource.TestCheckResourceAttr("data.grafana_notification_policy.current", "contact_point", "A Contact Point"),
+					resource.TestCheckResourceAttr("data.grafana_notification_policy.current", "group_by.0", "..."),
+					resource.TestCheckResourceAttr("data.grafana_notification_policy.current", "policy.#", "1"),
+					resource.TestCheckResourceAttr("data.grafana_notification_policy.current", "policy.0.matcher.0.label", "team"),
+					resource.TestCheckResourceAttr("data.grafana_notification_policy.current", "policy.0.mute_timings.0", "Some Mute Timing"),
+					resource.TestMatchResourceAttr("data.grafana_notification_policy.current", "alertmanager_yaml", regexp.MustCompile(`matchers:\s+- team="backend"`)),
+					resource.TestMatchResourceAttr("data.grafana_notification_policy.current", "alertmanager_yaml", regexp.MustCompile(`mute_time_intervals:\s+- name: Some Mute Timing\s+time_intervals:\s+- weekdays:\s+- saturday\s+- sunday`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAlertmanagerConfigYAML(t *testing.T) {
+	IsUnitTest(t)
+
+	tree, err := parsePolicyTree(`{
+		"receiver": "default",
+		"group_by": ["alertname"],
+		"group_wait": "30s",
+		"provenance": "api",
+		"routes": [{
+			"receiver": "backend",
+			"object_matchers": [["team", "=", "backend"], ["severity", "=~", "critical|page"]],
+			"mute_time_intervals": ["weekends"],
+			"continue": true,
+			"routes": [{"object_matchers": [["service", "!=", "say \"hi\""]], "repeat_interval": "90m"}]
+		}]
+	}`, policyTreeFormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	muteTimings := []muteTiming{
+		{Name: "weekends", TimeIntervals: []muteTimingTimeInterval{{Weekdays: []string{"saturday", "sunday"}, Location: "Europe/Paris"}}},
+		{Name: "nights", TimeIntervals: []muteTimingTimeInterval{{Times: []muteTimingTimeRange{{StartTime: "22:00", EndTime: "24:00"}}}}},
+	}
+
+	document, err := alertmanagerConfigYAML(tree, muteTimings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `route:
+    receiver: default
+    group_by:
+        - alertname
+    group_wait: 30s
+    routes:
+        - receiver: backend
+          continue: true
+          matchers:
+            - severity=~"critical|page"
+            - team="backend"
+          mute_time_intervals:
+            - weekends
+          routes:
+            - matchers:
+                - service!="say \"hi\""
+              repeat_interval: 1h30m
+mute_time_intervals:
+    - name: nights
+      time_intervals:
+        - times:
+            - start_time: "22:00"
+              end_time: "24:00"
+    - name: weekends
+      time_intervals:
+        - weekdays:
+            - saturday
+            - sunday
+          location: Europe/Paris
+`
+	if document != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, document)
+	}
+}
@@ -0,0 +1,83 @@
+package grafana
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DatasourceRuleGroup() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Data source for retrieving an alert rule group, with the same attributes as the ` + "`grafana_rule_group`" + ` resource.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/alerting/alerting-rules)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/alerting_provisioning/#alert-rules)
+
+This data source requires Grafana 9.1.0 or later.
+`,
+		ReadContext: dataSourceRuleGroupRead,
+		Schema: cloneResourceSchemaForDatasource(ResourceRuleGroup(), map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the rule group.",
+			},
+			"folder_uid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The UID of the folder that the group belongs to.",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The rules within the group.",
+				Elem: &schema.Resource{
+					Schema: computedSchemaForDatasource(alertRuleSchema()),
+				},
+			},
+			"disable_provenance": nil,
+		}),
+	}
+}
+
+func dataSourceRuleGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	group, err := client.alertRuleGroup(d.Get("folder_uid").(string), d.Get("name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	packed, err := packRuleGroupForDatasource(group)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(packGroupID(ruleKeyFromGroup(group)))
+	for k, v := range packed {
+		if err := d.Set(k, v); err != nil {
+			return diag.Errorf("error setting %s: %s", k, err)
+		}
+	}
+	return nil
+}
+
+// packRuleGroupForDatasource returns the attributes of a rule group, as they are read by the `grafana_rule_group` resource.
+// The data stages are all packed with their `model`.
+func packRuleGroupForDatasource(g alertRuleGroup) (map[string]interface{}, error) {
+	rules, err := packAlertRules(g.Rules, nil)
+	if err != nil {
+		return nil, err
+	}
+	packed := map[string]interface{}{
+		"name":             g.Title,
+		"folder_uid":       g.FolderUID,
+		"interval_seconds": g.Interval,
+		"rule":             rules,
+	}
+	for _, r := range g.Rules {
+		packed["org_id"] = r.OrgID
+	}
+	return packed, nil
+}
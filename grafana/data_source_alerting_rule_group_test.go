@@ -0,0 +1,36 @@
+package grafana
+
+import (
+	"testing"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceRuleGroup(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=9.1.0")
+
+	var group gapi.RuleGroup
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAlertRuleCheckDestroy(&group),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "data-sources/grafana_alert_rule_group/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testRuleGroupCheckExists("grafana_rule_group.my_rule_group", &group),
+					resource.TestCheckResourceAttrPair("data.grafana_alert_rule_group.from_name", "id", "grafana_rule_group.my_rule_group", "id"),
+					resource.TestCheckResourceAttr("data.grafana_alert_rule_group.from_name", "interval_seconds", "60"),
+					resource.TestCheckResourceAttr("data.grafana_alert_rule_group.from_name", "org_id", "1"),
+					resource.TestCheckResourceAttr("data.grafana_alert_rule_group.from_name", "rule.#", "1"),
+					resource.TestCheckResourceAttrPair("data.grafana_alert_rule_group.from_name", "rule.0.uid", "grafana_rule_group.my_rule_group", "rule.0.uid"),
+					resource.TestCheckResourceAttr("data.grafana_alert_rule_group.from_name", "rule.0.name", "My Alert Rule"),
+					resource.TestCheckResourceAttr("data.grafana_alert_rule_group.from_name", "rule.0.labels.team", "backend"),
+					resource.TestCheckResourceAttr("data.grafana_alert_rule_group.from_name", "rule.0.data.1.datasource_uid", "-100"),
+					resource.TestCheckResourceAttrSet("data.grafana_alert_rule_group.from_name", "rule.0.data.1.model"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,149 @@
+package grafana
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DatasourceAlertRules() *schema.Resource {
+	ruleGroupSchema := computedSchemaForDatasource(ResourceRuleGroup().Schema)
+	delete(ruleGroupSchema, "disable_provenance")
+
+	return &schema.Resource{
+		Description: `
+Data source for listing the alert rules of a Grafana instance, including the rules that are not managed by Terraform.
+The rules are returned in their rule groups, with the same attributes as the ` + "`grafana_rule_group`" + ` resource.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/alerting/alerting-rules)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/alerting_provisioning/#alert-rules)
+
+This data source requires Grafana 10.0.0 or later.
+`,
+		ReadContext: dataSourceAlertRulesRead,
+		Schema: map[string]*schema.Schema{
+			"folder_uid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the rules of the folder with this UID.",
+			},
+			"group_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the rules of the groups with this name.",
+			},
+			"labels": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Only return the rules that have all these labels, with the same values.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"rule_groups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The groups of the matching rules, sorted by folder UID and name. Each group only holds its matching rules.",
+				Elem: &schema.Resource{
+					Schema: ruleGroupSchema,
+				},
+			},
+			"rule_uids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The UIDs of all the matching rules.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceAlertRulesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	folderUID := d.Get("folder_uid").(string)
+	groupName := d.Get("group_name").(string)
+	labels := unpackMap(d.Get("labels"))
+
+	rules, err := client.alertRules()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The rules are listed without the intervals of their groups, so the matching groups are read
+	groupKeys := map[alertRuleGroupKey]bool{}
+	for _, r := range rules {
+		key := alertRuleGroupKey{folderUID: r.FolderUID, name: r.RuleGroup}
+		if (folderUID == "" || key.folderUID == folderUID) && (groupName == "" || key.name == groupName) && alertRuleHasLabels(r, labels) {
+			groupKeys[key] = true
+		}
+	}
+	sortedKeys := make([]alertRuleGroupKey, 0, len(groupKeys))
+	for key := range groupKeys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Slice(sortedKeys, func(i, j int) bool {
+		if sortedKeys[i].folderUID != sortedKeys[j].folderUID {
+			return sortedKeys[i].folderUID < sortedKeys[j].folderUID
+		}
+		return sortedKeys[i].name < sortedKeys[j].name
+	})
+
+	ruleGroups := make([]interface{}, 0, len(sortedKeys))
+	ruleUIDs := []string{}
+	for _, key := range sortedKeys {
+		group, err := client.alertRuleGroup(key.folderUID, key.name)
+		if err != nil {
+			if strings.HasPrefix(err.Error(), "status: 404") {
+				// The group was deleted after the rules were listed
+				continue
+			}
+			return diag.FromErr(err)
+		}
+
+		matching := make([]alertRule, 0, len(group.Rules))
+		for _, r := range group.Rules {
+			if alertRuleHasLabels(r, labels) {
+				matching = append(matching, r)
+				ruleUIDs = append(ruleUIDs, r.UID)
+			}
+		}
+		if len(matching) == 0 {
+			continue
+		}
+		group.Rules = matching
+
+		packed, err := packRuleGroupForDatasource(group)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ruleGroups = append(ruleGroups, packed)
+	}
+
+	filters, err := json.Marshal([]interface{}{folderUID, groupName, labels})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	hash := sha256.Sum256(filters)
+	d.SetId(fmt.Sprintf("%x", hash[:]))
+	if err := d.Set("rule_groups", ruleGroups); err != nil {
+		return diag.Errorf("error setting rule_groups: %s", err)
+	}
+	d.Set("rule_uids", ruleUIDs)
+
+	return nil
+}
+
+// alertRuleHasLabels tells whether a rule has all the given labels, with the same values.
+func alertRuleHasLabels(rule alertRule, labels map[string]string) bool {
+	for k, v := range labels {
+		if value, ok := rule.Labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
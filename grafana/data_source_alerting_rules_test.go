@@ -0,0 +1,53 @@
+package grafana
+
+import (
+	"testing"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceAlertRules(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=10.0.0")
+
+	var group gapi.RuleGroup
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAlertRuleCheckDestroy(&group),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "data-sources/grafana_alert_rules/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testRuleGroupCheckExists("grafana_rule_group.my_rule_group", &group),
+					resource.TestCheckResourceAttr("data.grafana_alert_rules.backend", "rule_groups.#", "1"),
+					resource.TestCheckResourceAttr("data.grafana_alert_rules.backend", "rule_groups.0.name", "My Rule Group"),
+					resource.TestCheckResourceAttr("data.grafana_alert_rules.backend", "rule_groups.0.interval_seconds", "60"),
+					resource.TestCheckResourceAttr("data.grafana_alert_rules.backend", "rule_groups.0.rule.#", "1"),
+					resource.TestCheckResourceAttr("data.grafana_alert_rules.backend", "rule_groups.0.rule.0.name", "My Alert Rule"),
+					resource.TestCheckResourceAttrPair("data.grafana_alert_rules.backend", "rule_uids.0", "grafana_rule_group.my_rule_group", "rule.0.uid"),
+				),
+			},
+		},
+	})
+}
+
+func TestAlertRuleHasLabels(t *testing.T) {
+	IsUnitTest(t)
+
+	rule := alertRule{AlertRule: gapi.AlertRule{Labels: map[string]string{"team": "backend", "severity": "page"}}}
+	for _, tc := range []struct {
+		labels   map[string]string
+		expected bool
+	}{
+		{labels: nil, expected: true},
+		{labels: map[string]string{"team": "backend"}, expected: true},
+		{labels: map[string]string{"team": "backend", "severity": "page"}, expected: true},
+		{labels: map[string]string{"team": "frontend"}, expected: false},
+		{labels: map[string]string{"team": "backend", "env": "prod"}, expected: false},
+	} {
+		if got := alertRuleHasLabels(rule, tc.labels); got != tc.expected {
+			t.Errorf("labels %v: expected %v, got %v", tc.labels, tc.expected, got)
+		}
+	}
+}
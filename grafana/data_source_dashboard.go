@@ -36,10 +36,18 @@ func DatasourceDashboard() *schema.Resource {
 				ExactlyOneOf: []string{"dashboard_id", "uid"},
 				Description:  "The uid of the Grafana dashboard. Specify either this or `dashboard_id`.",
 			},
+			"export_for_sharing": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Set to true to export the dashboard the same way as the \"Export for sharing externally\" option of Grafana. " +
+					"Data sources are replaced by `${DS_<NAME>}` inputs and constant variables by `${VAR_<NAME>}` inputs, which are listed in `__inputs`. " +
+					"The plugins used by the dashboard are listed in `__requires`.",
+			},
 			"config_json": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "The complete dashboard model JSON.",
+				Description: "The complete dashboard model JSON. It is in the shared form if `export_for_sharing` is set.",
 			},
 			"version": {
 				Type:        schema.TypeInt,
@@ -118,7 +126,13 @@ func dataSourceDashboardRead(ctx context.Context, d *schema.ResourceData, meta i
 	d.SetId(uid)
 	d.Set("uid", dashboard.Model["uid"].(string))
 	d.Set("dashboard_id", int64(dashboard.Model["id"].(float64)))
-	configJSONBytes, err := json.Marshal(dashboard.Model)
+	model := dashboard.Model
+	if d.Get("export_for_sharing").(bool) {
+		if model, err = exportDashboardForSharing(meta, model); err != nil {
+			return diag.Errorf("error exporting dashboard %s: %s", uid, err)
+		}
+	}
+	configJSONBytes, err := json.Marshal(model)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -132,3 +146,21 @@ func dataSourceDashboardRead(ctx context.Context, d *schema.ResourceData, meta i
 
 	return nil
 }
+
+// exportDashboardForSharing looks up the data sources and plugins used by the dashboard and exports it for sharing.
+func exportDashboardForSharing(meta interface{}, dashboard map[string]interface{}) (map[string]interface{}, error) {
+	c := meta.(*client)
+	datasources, err := c.gapi.DataSources()
+	if err != nil {
+		return nil, err
+	}
+	plugins, err := c.plugins()
+	if err != nil {
+		return nil, err
+	}
+	health, err := c.gapi.Health()
+	if err != nil {
+		return nil, err
+	}
+	return newDashboardExporter(datasources, plugins, health.Version).export(dashboard)
+}
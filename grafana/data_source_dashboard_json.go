@@ -0,0 +1,1072 @@
+package grafana
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// dashboardGridWidth is the number of columns of the dashboard grid
+	dashboardGridWidth = 24
+	// Size of the panels that don't have a `grid_pos` block
+	dashboardDefaultPanelWidth  = 12
+	dashboardDefaultPanelHeight = 8
+)
+
+var dashboardVariableTypes = []string{"query", "custom", "constant", "datasource", "interval", "textbox", "adhoc"}
+
+func DatasourceDashboardJSON() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Builds a dashboard model from typed blocks. The resulting JSON can be passed to the ` + "`config_json`" + ` attribute of the ` + "`grafana_dashboard`" + ` resource.
+This data source does not make any call to the Grafana API.
+
+Panels without a ` + "`grid_pos`" + ` block are laid out automatically, left to right, in the order they are declared.
+Top-level panels come first, followed by each row and its panels.
+
+* [Dashboard JSON model](https://grafana.com/docs/grafana/latest/dashboards/build-dashboards/view-dashboard-json-model/)
+`,
+		ReadContext: dataSourceDashboardJSONRead,
+		Schema: map[string]*schema.Schema{
+			"title": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The title of the dashboard.",
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The unique identifier of the dashboard. Grafana generates one if not set.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the dashboard.",
+			},
+			"tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Tags of the dashboard.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"timezone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "browser",
+				Description: "Timezone of the dashboard, `browser`, `utc` or an IANA timezone name.",
+			},
+			"editable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the dashboard can be edited in the UI.",
+			},
+			"graph_tooltip": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(0, 2),
+				Description:  "Crosshair and tooltip sharing between panels. `0` for none, `1` for a shared crosshair, `2` for a shared crosshair and tooltip.",
+			},
+			"refresh": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Auto-refresh interval of the dashboard, e.g. `30s`.",
+			},
+			"time_from": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "now-6h",
+				Description: "Start of the default time range.",
+			},
+			"time_to": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "now",
+				Description: "End of the default time range.",
+			},
+			"schema_version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     36,
+				Description: "Version of the dashboard JSON schema the blocks are written for.",
+			},
+			"variable": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Template variables of the dashboard.",
+				Elem:        dashboardJSONVariableSchema(),
+			},
+			"annotation": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Annotation queries of the dashboard.",
+				Elem:        dashboardJSONAnnotationSchema(),
+			},
+			"link": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Links displayed at the top of the dashboard.",
+				Elem:        dashboardJSONLinkSchema(),
+			},
+			"panel": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Panels displayed before the first row.",
+				Elem:        dashboardJSONPanelSchema(),
+			},
+			"row": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Rows of the dashboard, and the panels they contain.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"title": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The title of the row.",
+						},
+						"collapsed": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the row is collapsed. The panels of a collapsed row are nested in the row panel.",
+						},
+						"repeat": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Name of the variable to repeat the row for.",
+						},
+						"panel": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Panels of the row.",
+							Elem:        dashboardJSONPanelSchema(),
+						},
+					},
+				},
+			},
+			"config_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The dashboard model JSON, ready to be used in the `config_json` attribute of the `grafana_dashboard` resource.",
+			},
+		},
+	}
+}
+
+func dashboardJSONVariableSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the variable, as used in queries (`$name`).",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(dashboardVariableTypes, false),
+				Description:  allowedValuesDescription("Type of the variable", dashboardVariableTypes),
+			},
+			"label": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Label of the variable, displayed instead of the name.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the variable.",
+			},
+			"query": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Query of the variable. For `custom` and `interval` variables, this is a comma-separated list of values. For `datasource` variables, this is the data source type. For `constant` and `textbox` variables, this is the value.",
+			},
+			"datasource_uid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "UID of the data source used by `query` and `adhoc` variables.",
+			},
+			"datasource_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Type of the data source used by `query` and `adhoc` variables.",
+			},
+			"regex": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Regex to filter or capture the values of the variable.",
+			},
+			"refresh": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(0, 2),
+				Description:  "When to refresh the values of `query` variables. `0` never, `1` on dashboard load, `2` on time range change.",
+			},
+			"sort": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Sort order of the values of `query` variables.",
+			},
+			"multi": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether multiple values can be selected.",
+			},
+			"include_all": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether an `All` option is available.",
+			},
+			"all_value": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Custom value of the `All` option.",
+			},
+			"default": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Value selected when the dashboard is loaded.",
+			},
+			"hide": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(0, 2),
+				Description:  "`0` to display the variable, `1` to hide its label, `2` to hide the variable.",
+			},
+		},
+	}
+}
+
+func dashboardJSONAnnotationSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the annotation query.",
+			},
+			"datasource_uid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "UID of the data source to query.",
+			},
+			"datasource_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Type of the data source to query.",
+			},
+			"enable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the annotations are displayed.",
+			},
+			"hide": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the toggle of the annotation query is hidden.",
+			},
+			"icon_color": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "red",
+				Description: "Color of the annotations.",
+			},
+			"expr": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Query expression, for data sources such as Prometheus or Loki.",
+			},
+			"target_json": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: SuppressEquivalentJSONDiffs,
+				Description:      "Query model of the annotation, for data sources that don't use `expr`.",
+			},
+		},
+	}
+}
+
+func dashboardJSONLinkSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"title": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Title of the link.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "link",
+				ValidateFunc: validation.StringInSlice([]string{"link", "dashboards"}, false),
+				Description:  "`link` for a URL, `dashboards` for the dashboards matching `tags`.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URL of `link` links.",
+			},
+			"tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Tags of the dashboards to link to, for `dashboards` links.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"as_dropdown": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether `dashboards` links are displayed in a dropdown.",
+			},
+			"icon": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "external link",
+				Description: "Icon of the link.",
+			},
+			"tooltip": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Tooltip of the link.",
+			},
+			"include_vars": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the current variable values are passed to the link.",
+			},
+			"keep_time": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the current time range is passed to the link.",
+			},
+			"target_blank": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the link opens in a new tab.",
+			},
+		},
+	}
+}
+
+func dashboardJSONPanelSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"title": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Title of the panel.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Visualization of the panel, e.g. `timeseries`, `stat` or `table`.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the panel.",
+			},
+			"datasource_uid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "UID of the data source of the panel.",
+			},
+			"datasource_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Type of the data source of the panel.",
+			},
+			"grid_pos": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Position and size of the panel. Panels without one are laid out automatically.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"x": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, dashboardGridWidth-1),
+							Description:  "Column of the panel.",
+						},
+						"y": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "Line of the panel.",
+						},
+						"w": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(1, dashboardGridWidth),
+							Description:  "Width of the panel, out of 24 columns.",
+						},
+						"h": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Height of the panel.",
+						},
+					},
+				},
+			},
+			"target": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Queries of the panel.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ref_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Reference of the query. Defaults to `A`, `B`, `C`... in declaration order.",
+						},
+						"datasource_uid": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "UID of the data source of the query, if different from the panel's.",
+						},
+						"datasource_type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Type of the data source of the query, if different from the panel's.",
+						},
+						"expr": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Query expression, for data sources such as Prometheus or Loki.",
+						},
+						"legend_format": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Legend of the series returned by the query.",
+						},
+						"hide": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the query is disabled.",
+						},
+						"model_json": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     validation.StringIsJSON,
+							DiffSuppressFunc: SuppressEquivalentJSONDiffs,
+							Description:      "Additional fields of the query model, for data sources that don't use `expr`.",
+						},
+					},
+				},
+			},
+			"field_config": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Default configuration of the fields displayed by the panel.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"unit": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Unit of the values, e.g. `s` or `percent`.",
+						},
+						"decimals": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      -1,
+							ValidateFunc: validation.IntAtLeast(-1),
+							Description:  "Number of decimals to display. `-1` lets Grafana decide.",
+						},
+						"min": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Minimum of the values.",
+						},
+						"max": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Maximum of the values.",
+						},
+						"no_value": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Text displayed when there is no value.",
+						},
+						"color_mode": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Color scheme of the values, e.g. `palette-classic` or `thresholds`.",
+						},
+						"threshold_mode": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "absolute",
+							ValidateFunc: validation.StringInSlice([]string{"absolute", "percentage"}, false),
+							Description:  "Whether threshold values are `absolute` or a `percentage`.",
+						},
+						"threshold": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Thresholds of the values. The first one is the base color and should not have a value.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"color": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Color of the threshold.",
+									},
+									"value": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Value from which the threshold applies.",
+									},
+								},
+							},
+						},
+						"custom_json": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     validation.StringIsJSON,
+							DiffSuppressFunc: SuppressEquivalentJSONDiffs,
+							Description:      "Visualization specific field configuration (`fieldConfig.defaults.custom`).",
+						},
+					},
+				},
+			},
+			"override": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Field configuration overrides.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"matcher_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Matcher of the fields to override, e.g. `byName` or `byRegexp`.",
+						},
+						"matcher_options": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Option of the matcher, e.g. the field name.",
+						},
+						"property": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Description: "Properties to override.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Property to override, e.g. `unit` or `color`.",
+									},
+									"value_json": {
+										Type:             schema.TypeString,
+										Required:         true,
+										ValidateFunc:     validation.StringIsJSON,
+										DiffSuppressFunc: SuppressEquivalentJSONDiffs,
+										Description:      "JSON value of the property.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"options_json": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: SuppressEquivalentJSONDiffs,
+				Description:      "Visualization specific options of the panel.",
+			},
+			"transparent": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the panel has a transparent background.",
+			},
+			"repeat": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the variable to repeat the panel for.",
+			},
+			"repeat_direction": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "h",
+				ValidateFunc: validation.StringInSlice([]string{"h", "v"}, false),
+				Description:  "Direction of the repeated panels, `h` or `v`.",
+			},
+			"interval": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Minimum interval of the queries.",
+			},
+			"max_data_points": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum number of data points of the queries.",
+			},
+		},
+	}
+}
+
+func dataSourceDashboardJSONRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	model, err := buildDashboardJSONModel(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only the normalization is applied here, the data source output must always be the full model
+	normalizeDashboardModel(model)
+	configJSON, err := json.Marshal(model)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	hash := sha256.Sum256(configJSON)
+	d.SetId(fmt.Sprintf("%x", hash[:]))
+	d.Set("config_json", string(configJSON))
+
+	return nil
+}
+
+func buildDashboardJSONModel(d *schema.ResourceData) (map[string]interface{}, error) {
+	model := map[string]interface{}{
+		"title":        d.Get("title").(string),
+		"tags":         listToStringSlice(d.Get("tags").([]interface{})),
+		"timezone":     d.Get("timezone").(string),
+		"editable":     d.Get("editable").(bool),
+		"graphTooltip": d.Get("graph_tooltip").(int),
+		"refresh":      d.Get("refresh").(string),
+		"time": map[string]interface{}{
+			"from": d.Get("time_from").(string),
+			"to":   d.Get("time_to").(string),
+		},
+		"schemaVersion": d.Get("schema_version").(int),
+	}
+	if uid := d.Get("uid").(string); uid != "" {
+		model["uid"] = uid
+	}
+	if description := d.Get("description").(string); description != "" {
+		model["description"] = description
+	}
+
+	variables := []interface{}{}
+	for _, v := range d.Get("variable").([]interface{}) {
+		variables = append(variables, buildDashboardJSONVariable(v.(map[string]interface{})))
+	}
+	model["templating"] = map[string]interface{}{"list": variables}
+
+	annotations := []interface{}{}
+	for _, a := range d.Get("annotation").([]interface{}) {
+		annotation, err := buildDashboardJSONAnnotation(a.(map[string]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		annotations = append(annotations, annotation)
+	}
+	model["annotations"] = map[string]interface{}{"list": annotations}
+
+	links := []interface{}{}
+	for _, l := range d.Get("link").([]interface{}) {
+		links = append(links, buildDashboardJSONLink(l.(map[string]interface{})))
+	}
+	model["links"] = links
+
+	layout := &dashboardJSONLayout{}
+	panels, err := buildDashboardJSONPanels(d.Get("panel").([]interface{}), layout)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range d.Get("row").([]interface{}) {
+		row := r.(map[string]interface{})
+		rowPanel := map[string]interface{}{
+			"type":      "row",
+			"title":     row["title"].(string),
+			"collapsed": row["collapsed"].(bool),
+			"gridPos":   layout.row(),
+		}
+		if repeat := row["repeat"].(string); repeat != "" {
+			rowPanel["repeat"] = repeat
+		}
+		rowPanels, err := buildDashboardJSONPanels(row["panel"].([]interface{}), layout)
+		if err != nil {
+			return nil, fmt.Errorf("row %q: %w", row["title"], err)
+		}
+		if row["collapsed"].(bool) {
+			rowPanel["panels"] = rowPanels
+			panels = append(panels, rowPanel)
+		} else {
+			rowPanel["panels"] = []interface{}{}
+			panels = append(panels, rowPanel)
+			panels = append(panels, rowPanels...)
+		}
+	}
+	model["panels"] = panels
+
+	return model, nil
+}
+
+func buildDashboardJSONVariable(variable map[string]interface{}) map[string]interface{} {
+	variableType := variable["type"].(string)
+	query := variable["query"].(string)
+	result := map[string]interface{}{
+		"name":        variable["name"].(string),
+		"type":        variableType,
+		"hide":        variable["hide"].(int),
+		"skipUrlSync": false,
+	}
+	if label := variable["label"].(string); label != "" {
+		result["label"] = label
+	}
+	if description := variable["description"].(string); description != "" {
+		result["description"] = description
+	}
+	if datasource := dashboardJSONDatasource(variable["datasource_uid"].(string), variable["datasource_type"].(string)); datasource != nil {
+		result["datasource"] = datasource
+	}
+	if variableType != "adhoc" {
+		result["query"] = query
+	}
+
+	switch variableType {
+	case "query":
+		result["definition"] = query
+		result["refresh"] = variable["refresh"].(int)
+		result["sort"] = variable["sort"].(int)
+		result["regex"] = variable["regex"].(string)
+	case "datasource":
+		result["refresh"] = 1
+		result["regex"] = variable["regex"].(string)
+	case "custom", "interval":
+		options := []interface{}{}
+		for _, value := range strings.Split(query, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				options = append(options, map[string]interface{}{"text": value, "value": value, "selected": value == variable["default"].(string)})
+			}
+		}
+		result["options"] = options
+	}
+
+	switch variableType {
+	case "query", "custom", "datasource":
+		result["multi"] = variable["multi"].(bool)
+		result["includeAll"] = variable["include_all"].(bool)
+		if allValue := variable["all_value"].(string); allValue != "" {
+			result["allValue"] = allValue
+		}
+	}
+
+	if value := variable["default"].(string); value != "" {
+		result["current"] = map[string]interface{}{"text": value, "value": value}
+	}
+
+	return result
+}
+
+func buildDashboardJSONAnnotation(annotation map[string]interface{}) (map[string]interface{}, error) {
+	result := map[string]interface{}{
+		"name":       annotation["name"].(string),
+		"datasource": dashboardJSONDatasource(annotation["datasource_uid"].(string), annotation["datasource_type"].(string)),
+		"enable":     annotation["enable"].(bool),
+		"hide":       annotation["hide"].(bool),
+		"iconColor":  annotation["icon_color"].(string),
+	}
+	if expr := annotation["expr"].(string); expr != "" {
+		result["expr"] = expr
+	}
+	if targetJSON := annotation["target_json"].(string); targetJSON != "" {
+		target, err := dashboardJSONObject(targetJSON)
+		if err != nil {
+			return nil, fmt.Errorf("annotation %q: invalid target_json: %w", annotation["name"], err)
+		}
+		result["target"] = target
+	}
+	return result, nil
+}
+
+func buildDashboardJSONLink(link map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"title":       link["title"].(string),
+		"type":        link["type"].(string),
+		"url":         link["url"].(string),
+		"tags":        listToStringSlice(link["tags"].([]interface{})),
+		"asDropdown":  link["as_dropdown"].(bool),
+		"icon":        link["icon"].(string),
+		"tooltip":     link["tooltip"].(string),
+		"includeVars": link["include_vars"].(bool),
+		"keepTime":    link["keep_time"].(bool),
+		"targetBlank": link["target_blank"].(bool),
+	}
+}
+
+func buildDashboardJSONPanels(panels []interface{}, layout *dashboardJSONLayout) ([]interface{}, error) {
+	result := []interface{}{}
+	for _, p := range panels {
+		panel, err := buildDashboardJSONPanel(p.(map[string]interface{}), layout)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, panel)
+	}
+	return result, nil
+}
+
+func buildDashboardJSONPanel(panel map[string]interface{}, layout *dashboardJSONLayout) (map[string]interface{}, error) {
+	title := panel["title"].(string)
+	result := map[string]interface{}{
+		"type":        panel["type"].(string),
+		"title":       title,
+		"transparent": panel["transparent"].(bool),
+	}
+	if description := panel["description"].(string); description != "" {
+		result["description"] = description
+	}
+	if datasource := dashboardJSONDatasource(panel["datasource_uid"].(string), panel["datasource_type"].(string)); datasource != nil {
+		result["datasource"] = datasource
+	}
+	if repeat := panel["repeat"].(string); repeat != "" {
+		result["repeat"] = repeat
+		result["repeatDirection"] = panel["repeat_direction"].(string)
+	}
+	if interval := panel["interval"].(string); interval != "" {
+		result["interval"] = interval
+	}
+	if maxDataPoints := panel["max_data_points"].(int); maxDataPoints > 0 {
+		result["maxDataPoints"] = maxDataPoints
+	}
+
+	if gridPos := panel["grid_pos"].([]interface{}); len(gridPos) > 0 && gridPos[0] != nil {
+		pos := gridPos[0].(map[string]interface{})
+		result["gridPos"] = layout.fixed(pos["x"].(int), pos["y"].(int), pos["w"].(int), pos["h"].(int))
+	} else {
+		result["gridPos"] = layout.next(dashboardDefaultPanelWidth, dashboardDefaultPanelHeight)
+	}
+
+	targets := []interface{}{}
+	for i, t := range panel["target"].([]interface{}) {
+		target, err := buildDashboardJSONTarget(t.(map[string]interface{}), i)
+		if err != nil {
+			return nil, fmt.Errorf("panel %q: %w", title, err)
+		}
+		targets = append(targets, target)
+	}
+	result["targets"] = targets
+
+	fieldConfig, err := buildDashboardJSONFieldConfig(panel)
+	if err != nil {
+		return nil, fmt.Errorf("panel %q: %w", title, err)
+	}
+	result["fieldConfig"] = fieldConfig
+
+	options := map[string]interface{}{}
+	if optionsJSON := panel["options_json"].(string); optionsJSON != "" {
+		if options, err = dashboardJSONObject(optionsJSON); err != nil {
+			return nil, fmt.Errorf("panel %q: invalid options_json: %w", title, err)
+		}
+	}
+	result["options"] = options
+
+	return result, nil
+}
+
+func buildDashboardJSONTarget(target map[string]interface{}, index int) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	if modelJSON := target["model_json"].(string); modelJSON != "" {
+		var err error
+		if result, err = dashboardJSONObject(modelJSON); err != nil {
+			return nil, fmt.Errorf("invalid model_json: %w", err)
+		}
+	}
+
+	refID := target["ref_id"].(string)
+	if refID == "" {
+		refID = dashboardJSONRefID(index)
+	}
+	result["refId"] = refID
+	if target["hide"].(bool) {
+		result["hide"] = true
+	}
+	if datasource := dashboardJSONDatasource(target["datasource_uid"].(string), target["datasource_type"].(string)); datasource != nil {
+		result["datasource"] = datasource
+	}
+	if expr := target["expr"].(string); expr != "" {
+		result["expr"] = expr
+	}
+	if legendFormat := target["legend_format"].(string); legendFormat != "" {
+		result["legendFormat"] = legendFormat
+	}
+	return result, nil
+}
+
+func buildDashboardJSONFieldConfig(panel map[string]interface{}) (map[string]interface{}, error) {
+	defaults := map[string]interface{}{}
+	if fieldConfig := panel["field_config"].([]interface{}); len(fieldConfig) > 0 && fieldConfig[0] != nil {
+		config := fieldConfig[0].(map[string]interface{})
+		if unit := config["unit"].(string); unit != "" {
+			defaults["unit"] = unit
+		}
+		if decimals := config["decimals"].(int); decimals >= 0 {
+			defaults["decimals"] = decimals
+		}
+		for _, key := range []string{"min", "max"} {
+			if value := config[key].(string); value != "" {
+				number, err := dashboardJSONNumber(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid field_config %s: %w", key, err)
+				}
+				defaults[key] = number
+			}
+		}
+		if noValue := config["no_value"].(string); noValue != "" {
+			defaults["noValue"] = noValue
+		}
+		if colorMode := config["color_mode"].(string); colorMode != "" {
+			defaults["color"] = map[string]interface{}{"mode": colorMode}
+		}
+		if thresholds := config["threshold"].([]interface{}); len(thresholds) > 0 {
+			steps := []interface{}{}
+			for _, t := range thresholds {
+				threshold := t.(map[string]interface{})
+				var value interface{}
+				if v := threshold["value"].(string); v != "" {
+					number, err := dashboardJSONNumber(v)
+					if err != nil {
+						return nil, fmt.Errorf("invalid threshold value: %w", err)
+					}
+					value = number
+				}
+				steps = append(steps, map[string]interface{}{"color": threshold["color"].(string), "value": value})
+			}
+			defaults["thresholds"] = map[string]interface{}{"mode": config["threshold_mode"].(string), "steps": steps}
+		}
+		if customJSON := config["custom_json"].(string); customJSON != "" {
+			custom, err := dashboardJSONObject(customJSON)
+			if err != nil {
+				return nil, fmt.Errorf("invalid field_config custom_json: %w", err)
+			}
+			defaults["custom"] = custom
+		}
+	}
+
+	overrides := []interface{}{}
+	for _, o := range panel["override"].([]interface{}) {
+		override := o.(map[string]interface{})
+		properties := []interface{}{}
+		for _, p := range override["property"].([]interface{}) {
+			property := p.(map[string]interface{})
+			var value interface{}
+			if err := json.Unmarshal([]byte(property["value_json"].(string)), &value); err != nil {
+				return nil, fmt.Errorf("invalid value_json for override property %q: %w", property["id"], err)
+			}
+			properties = append(properties, map[string]interface{}{"id": property["id"].(string), "value": value})
+		}
+		overrides = append(overrides, map[string]interface{}{
+			"matcher":    map[string]interface{}{"id": override["matcher_id"].(string), "options": override["matcher_options"].(string)},
+			"properties": properties,
+		})
+	}
+
+	return map[string]interface{}{"defaults": defaults, "overrides": overrides}, nil
+}
+
+// dashboardJSONLayout places panels on the dashboard grid.
+// Panels are placed left to right, and wrap to a new line when they don't fit in the remaining width.
+type dashboardJSONLayout struct {
+	x, y int
+	// bottom is the lowest line occupied by a panel so far
+	bottom int
+}
+
+func (l *dashboardJSONLayout) next(w, h int) map[string]interface{} {
+	if l.x+w > dashboardGridWidth {
+		l.newLine()
+	}
+	pos := dashboardJSONGridPos(l.x, l.y, w, h)
+	l.x += w
+	if l.y+h > l.bottom {
+		l.bottom = l.y + h
+	}
+	return pos
+}
+
+// fixed records a panel with an explicit position. Panels placed after it start on a new line below it.
+func (l *dashboardJSONLayout) fixed(x, y, w, h int) map[string]interface{} {
+	if y+h > l.bottom {
+		l.bottom = y + h
+	}
+	l.newLine()
+	return dashboardJSONGridPos(x, y, w, h)
+}
+
+func (l *dashboardJSONLayout) row() map[string]interface{} {
+	l.newLine()
+	return l.next(dashboardGridWidth, 1)
+}
+
+func (l *dashboardJSONLayout) newLine() {
+	l.x = 0
+	l.y = l.bottom
+}
+
+func dashboardJSONGridPos(x, y, w, h int) map[string]interface{} {
+	return map[string]interface{}{"x": x, "y": y, "w": w, "h": h}
+}
+
+func dashboardJSONDatasource(uid, datasourceType string) map[string]interface{} {
+	if uid == "" && datasourceType == "" {
+		return nil
+	}
+	datasource := map[string]interface{}{}
+	if uid != "" {
+		datasource["uid"] = uid
+	}
+	if datasourceType != "" {
+		datasource["type"] = datasourceType
+	}
+	return datasource
+}
+
+// dashboardJSONRefID returns the default query reference for the given index: A, B, ..., Z, AA, AB, ...
+func dashboardJSONRefID(index int) string {
+	refID := ""
+	for index >= 0 {
+		refID = string(rune('A'+index%26)) + refID
+		index = index/26 - 1
+	}
+	return refID
+}
+
+func dashboardJSONObject(value string) (map[string]interface{}, error) {
+	var object map[string]interface{}
+	err := json.Unmarshal([]byte(value), &object)
+	return object, err
+}
+
+func dashboardJSONNumber(value string) (float64, error) {
+	var number float64
+	err := json.Unmarshal([]byte(value), &number)
+	return number, err
+}
@@ -0,0 +1,148 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccDatasourceDashboardJSON(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+
+	var dashboard gapi.Dashboard
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccDashboardCheckDestroy(&dashboard),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "data-sources/grafana_dashboard_json/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.overview", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.overview", "uid", "service-overview"),
+					resource.TestCheckResourceAttrPair("grafana_dashboard.overview", "config_json", "data.grafana_dashboard_json.overview", "config_json"),
+				),
+			},
+		},
+	})
+}
+
+func TestDatasourceDashboardJSONRead(t *testing.T) {
+	IsUnitTest(t)
+
+	d := schema.TestResourceDataRaw(t, DatasourceDashboardJSON().Schema, map[string]interface{}{
+		"title": "Test",
+		"variable": []interface{}{
+			map[string]interface{}{"name": "env", "type": "custom", "query": "dev, prod", "default": "prod"},
+		},
+		"panel": []interface{}{
+			map[string]interface{}{
+				"title": "first",
+				"type":  "stat",
+				"target": []interface{}{
+					map[string]interface{}{"expr": "up"},
+					map[string]interface{}{"expr": "down", "model_json": `{"instant":true}`},
+				},
+			},
+			map[string]interface{}{"title": "second", "type": "stat"},
+			map[string]interface{}{"title": "third", "type": "stat"},
+		},
+		"row": []interface{}{
+			map[string]interface{}{
+				"title":     "collapsed",
+				"collapsed": true,
+				"panel": []interface{}{
+					map[string]interface{}{
+						"title": "nested",
+						"type":  "timeseries",
+						"field_config": []interface{}{
+							map[string]interface{}{
+								"unit":      "s",
+								"threshold": []interface{}{map[string]interface{}{"color": "green"}, map[string]interface{}{"color": "red", "value": "80"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	if diags := dataSourceDashboardJSONRead(context.Background(), d, nil); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var model struct {
+		SchemaVersion int `json:"schemaVersion"`
+		Templating    struct {
+			List []struct {
+				Options []map[string]interface{} `json:"options"`
+				Current map[string]interface{}   `json:"current"`
+			} `json:"list"`
+		} `json:"templating"`
+		Panels []struct {
+			Title   string                   `json:"title"`
+			GridPos map[string]int           `json:"gridPos"`
+			Targets []map[string]interface{} `json:"targets"`
+			Panels  []struct {
+				GridPos     map[string]int `json:"gridPos"`
+				FieldConfig struct {
+					Defaults map[string]interface{} `json:"defaults"`
+				} `json:"fieldConfig"`
+			} `json:"panels"`
+		} `json:"panels"`
+	}
+	if err := json.Unmarshal([]byte(d.Get("config_json").(string)), &model); err != nil {
+		t.Fatal(err)
+	}
+
+	if model.SchemaVersion != 36 {
+		t.Errorf("expected schemaVersion 36, got %d", model.SchemaVersion)
+	}
+	if options := model.Templating.List[0].Options; len(options) != 2 || options[1]["value"] != "prod" || options[1]["selected"] != true {
+		t.Errorf("unexpected variable options: %v", options)
+	}
+	if len(model.Panels) != 4 {
+		t.Fatalf("expected 4 top-level panels, got %d", len(model.Panels))
+	}
+
+	targets := model.Panels[0].Targets
+	if targets[0]["refId"] != "A" || targets[1]["refId"] != "B" || targets[1]["instant"] != true {
+		t.Errorf("unexpected targets: %v", targets)
+	}
+
+	expectedPositions := []map[string]int{
+		{"x": 0, "y": 0, "w": 12, "h": 8},
+		{"x": 12, "y": 0, "w": 12, "h": 8},
+		{"x": 0, "y": 8, "w": 12, "h": 8},
+		{"x": 0, "y": 16, "w": 24, "h": 1},
+	}
+	for i, expected := range expectedPositions {
+		for k, v := range expected {
+			if model.Panels[i].GridPos[k] != v {
+				t.Errorf("panel %q: expected gridPos %v, got %v", model.Panels[i].Title, expected, model.Panels[i].GridPos)
+				break
+			}
+		}
+	}
+
+	nested := model.Panels[3].Panels
+	if len(nested) != 1 || nested[0].GridPos["y"] != 17 {
+		t.Fatalf("unexpected collapsed row panels: %+v", nested)
+	}
+	thresholds := nested[0].FieldConfig.Defaults["thresholds"].(map[string]interface{})["steps"].([]interface{})
+	if thresholds[0].(map[string]interface{})["value"] != nil || thresholds[1].(map[string]interface{})["value"] != float64(80) {
+		t.Errorf("unexpected thresholds: %v", thresholds)
+	}
+}
+
+func TestDashboardJSONRefID(t *testing.T) {
+	IsUnitTest(t)
+
+	for index, expected := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := dashboardJSONRefID(index); got != expected {
+			t.Errorf("expected %q for index %d, got %q", expected, index, got)
+		}
+	}
+}
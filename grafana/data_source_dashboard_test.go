@@ -51,6 +51,30 @@ func TestAccDatasourceDashboardBasicID(t *testing.T) {
 	})
 }
 
+func TestAccDatasourceDashboardExportForSharing(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+
+	var dashboard gapi.Dashboard
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccDashboardCheckDestroy(&dashboard),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "data-sources/grafana_dashboard/export-for-sharing.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("data.grafana_dashboard.exported", "title", "Production Overview"),
+					resource.TestMatchResourceAttr("data.grafana_dashboard.exported", "config_json", regexp.MustCompile(`"__inputs":\[\{"description":"","label":"Staging Prometheus","name":"DS_STAGING_PROMETHEUS","pluginId":"prometheus","pluginName":"Prometheus","type":"datasource"\}\]`)),
+					resource.TestMatchResourceAttr("data.grafana_dashboard.exported", "config_json", regexp.MustCompile(`"datasource":\{"type":"prometheus","uid":"\$\{DS_STAGING_PROMETHEUS\}"\}`)),
+					resource.TestMatchResourceAttr("data.grafana_dashboard.exported", "config_json", regexp.MustCompile(`\{"id":"timeseries","name":"Time series","type":"panel","version":""\}`)),
+					resource.TestMatchResourceAttr("data.grafana_dashboard.exported", "config_json", regexp.MustCompile(`"id":null`)),
+				),
+			},
+		},
+	})
+}
+
 func TestAccDatasourceDashboardBadExactlyOneOf(t *testing.T) {
 	CheckOSSTestsEnabled(t)
 
@@ -0,0 +1,110 @@
+package grafana
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DatasourceDashboardVersions() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Lists the versions of a dashboard, from the most recent.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/build-dashboards/manage-version-history/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/dashboard_versions/)
+`,
+		ReadContext: dataSourceDashboardVersionsRead,
+		Schema: map[string]*schema.Schema{
+			"dashboard_uid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The UID of the dashboard.",
+			},
+			"limit": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Maximum number of versions to return. `0` uses the default of the Grafana API.",
+			},
+			"versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The versions of the dashboard.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The numerical ID of the version.",
+						},
+						"version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version number.",
+						},
+						"parent_version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version this version was saved from.",
+						},
+						"restored_from": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version this version restored, if it was created by a restore.",
+						},
+						"created": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "When the version was saved, in RFC 3339 format.",
+						},
+						"created_by": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The login of the user who saved the version.",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The commit message of the version.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDashboardVersionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+	uid := d.Get("dashboard_uid").(string)
+
+	dashboard, err := client.gapi.DashboardByUID(uid)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	versions, err := client.dashboardVersions(int64(dashboard.Model["id"].(float64)), d.Get("limit").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	versionList := make([]interface{}, 0, len(versions))
+	for _, version := range versions {
+		versionList = append(versionList, map[string]interface{}{
+			"id":             version.ID,
+			"version":        version.Version,
+			"parent_version": version.ParentVersion,
+			"restored_from":  version.RestoredFrom,
+			"created":        version.Created.Format(time.RFC3339),
+			"created_by":     version.CreatedBy,
+			"message":        version.Message,
+		})
+	}
+
+	d.SetId(uid)
+	d.Set("versions", versionList)
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package grafana
+
+import (
+	"testing"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceDashboardVersions(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+
+	var dashboard gapi.Dashboard
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccDashboardCheckDestroy(&dashboard),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "data-sources/grafana_dashboard_versions/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "id", "versions-ds-dashboard"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.#", "1"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.0.version", "1"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.0.message", "Initial version"),
+					resource.TestCheckResourceAttrSet("data.grafana_dashboard_versions.test", "versions.0.created"),
+					resource.TestCheckResourceAttrSet("data.grafana_dashboard_versions.test", "versions.0.created_by"),
+				),
+			},
+		},
+	})
+}
@@ -6,16 +6,19 @@ import (
 	"fmt"
 	"net/url"
 	"sort"
+	"strconv"
 	"strings"
 
+	gapi "github.com/grafana/grafana-api-golang-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func DatasourceDashboards() *schema.Resource {
 	return &schema.Resource{
 		Description: `
-Datasource for retrieving all dashboards. Specify list of folder IDs to search in for dashboards.
+Datasource for searching dashboards. All the filters are optional, and results are fetched page by page up to ` + "`limit`" + `.
 
 * [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/)
 * [Folder/Dashboard Search HTTP API](https://grafana.com/docs/grafana/latest/http_api/folder_dashboard_search/)
@@ -29,11 +32,42 @@ Datasource for retrieving all dashboards. Specify list of folder IDs to search i
 				Description: "Numerical IDs of Grafana folders containing dashboards. Specify to filter for dashboards by folder (eg. `[0]` for General folder), or leave blank to get all dashboards in all folders.",
 				Elem:        &schema.Schema{Type: schema.TypeInt},
 			},
-			"limit": {
-				Type:        schema.TypeInt,
+			"folder_uids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "UIDs of Grafana folders containing dashboards. Specify to filter for dashboards by folder.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"dashboard_uids": {
+				Type:        schema.TypeList,
 				Optional:    true,
-				Default:     5000,
-				Description: "Maximum number of dashboard search results to return.",
+				Description: "UIDs of the dashboards to return.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"query": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Search query, matched against the dashboard titles.",
+			},
+			"starred": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Set to true to only return the dashboards starred by the user of the provider.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "dash-db",
+				ValidateFunc: validation.StringInSlice([]string{"dash-db", "dash-folder"}, false),
+				Description:  "The type of results: `dash-db` for dashboards, `dash-folder` for folders.",
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5000,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of dashboard search results to return. Results are fetched in pages, so it can be above the page size limit of Grafana. Set to `0` to return all results.",
 			},
 			"tags": {
 				Type:        schema.TypeList,
@@ -46,6 +80,10 @@ Datasource for retrieving all dashboards. Specify list of folder IDs to search i
 				Computed: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
 						"title": {
 							Type:     schema.TypeString,
 							Computed: true,
@@ -54,6 +92,20 @@ Datasource for retrieving all dashboards. Specify list of folder IDs to search i
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The full URL of the dashboard.",
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"folder_uid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 						"folder_title": {
 							Type:     schema.TypeString,
 							Computed: true,
@@ -81,12 +133,47 @@ func hashDashboardSearchParameters(params map[string][]string) string {
 	return fmt.Sprintf("%x", hashOut.Sum(nil))[:23]
 }
 
+// dashboardSearchPageSize is the number of results requested per page when searching dashboards.
+// Grafana doesn't return more than 5000 results per page.
+const dashboardSearchPageSize = 1000
+
+// searchDashboards fetches the results of a dashboard search page by page, up to limit results (all results if limit is 0).
+func searchDashboards(client *gapi.Client, params url.Values, limit, pageSize int) ([]gapi.FolderDashboardSearchResponse, error) {
+	if limit > 0 && limit < pageSize {
+		pageSize = limit
+	}
+
+	var results []gapi.FolderDashboardSearchResponse
+	for page := 1; ; page++ {
+		pageParams := url.Values{}
+		for key, values := range params {
+			pageParams[key] = values
+		}
+		pageParams.Set("limit", strconv.Itoa(pageSize))
+		pageParams.Set("page", strconv.Itoa(page))
+
+		pageResults, err := client.FolderDashboardSearch(pageParams)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, pageResults...)
+
+		if limit > 0 && len(results) >= limit {
+			return results[:limit], nil
+		}
+		if len(pageResults) < pageSize {
+			return results, nil
+		}
+	}
+}
+
 func dataSourceReadDashboards(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	gapiURL := meta.(*client).gapiURL
 	client := meta.(*client).gapi
 	var diags diag.Diagnostics
 	params := url.Values{
 		"limit": {fmt.Sprint(d.Get("limit"))},
-		"type":  {"dash-db"},
+		"type":  {d.Get("type").(string)},
 	}
 
 	// add tags and folder IDs from attributes to dashboard search parameters
@@ -102,9 +189,29 @@ func dataSourceReadDashboards(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
+	if list, ok := d.GetOk("folder_uids"); ok {
+		for _, elem := range list.([]interface{}) {
+			params.Add("folderUIDs", fmt.Sprint(elem))
+		}
+	}
+
+	if list, ok := d.GetOk("dashboard_uids"); ok {
+		for _, elem := range list.([]interface{}) {
+			params.Add("dashboardUIDs", fmt.Sprint(elem))
+		}
+	}
+
+	if query := d.Get("query").(string); query != "" {
+		params.Set("query", query)
+	}
+
+	if d.Get("starred").(bool) {
+		params.Set("starred", "true")
+	}
+
 	d.SetId(hashDashboardSearchParameters(params))
 
-	results, err := client.FolderDashboardSearch(params)
+	results, err := searchDashboards(client, params, d.Get("limit").(int), dashboardSearchPageSize)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -112,8 +219,12 @@ func dataSourceReadDashboards(ctx context.Context, d *schema.ResourceData, meta
 	dashboards := make([]map[string]interface{}, len(results))
 	for i, result := range results {
 		dashboards[i] = map[string]interface{}{
+			"id":           int(result.ID),
 			"title":        result.Title,
 			"uid":          result.UID,
+			"url":          strings.TrimRight(gapiURL, "/") + result.URL,
+			"tags":         result.Tags,
+			"folder_uid":   result.FolderUID,
 			"folder_title": result.FolderTitle,
 		}
 	}
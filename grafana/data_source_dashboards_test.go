@@ -1,9 +1,16 @@
 package grafana
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"os"
+	"strconv"
+	"strings"
 	"testing"
 
+	gapi "github.com/grafana/grafana-api-golang-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -26,6 +33,16 @@ func TestAccDataSourceDashboardsAllAndByFolderID(t *testing.T) {
 		resource.TestCheckResourceAttr("data.grafana_dashboards.folder_ids", "dashboards.#", "1"),
 		resource.TestCheckResourceAttr("data.grafana_dashboards.folder_ids_tags", "dashboards.#", "1"),
 		resource.TestCheckResourceAttr("data.grafana_dashboards.limit_one", "dashboards.#", "1"),
+		resource.TestCheckResourceAttr("data.grafana_dashboards.folder_uids", "dashboards.#", "1"),
+		resource.TestCheckResourceAttr("data.grafana_dashboards.query", "dashboards.#", "1"),
+		resource.TestCheckResourceAttr("data.grafana_dashboards.dashboard_uids", "dashboards.#", "1"),
+		resource.TestCheckResourceAttr("data.grafana_dashboards.dashboard_uids", "dashboards.0.uid", "data-source-dashboards-1"),
+		resource.TestCheckResourceAttr("data.grafana_dashboards.dashboard_uids", "dashboards.0.title", "data_source_dashboards 1"),
+		resource.TestMatchResourceAttr("data.grafana_dashboards.dashboard_uids", "dashboards.0.id", idRegexp),
+		resource.TestCheckResourceAttr("data.grafana_dashboards.dashboard_uids", "dashboards.0.url", strings.TrimRight(os.Getenv("GRAFANA_URL"), "/")+"/d/data-source-dashboards-1/data_source_dashboards-1"),
+		resource.TestCheckResourceAttr("data.grafana_dashboards.dashboard_uids", "dashboards.0.tags.#", "1"),
+		resource.TestCheckResourceAttr("data.grafana_dashboards.dashboard_uids", "dashboards.0.tags.0", "data_source_dashboards"),
+		resource.TestCheckResourceAttrPair("data.grafana_dashboards.dashboard_uids", "dashboards.0.folder_uid", "grafana_folder.data_source_dashboards", "uid"),
 		resource.TestCheckResourceAttrSet("data.grafana_dashboard.from_data_source", "config_json"),
 	}
 
@@ -39,3 +56,50 @@ func TestAccDataSourceDashboardsAllAndByFolderID(t *testing.T) {
 		},
 	})
 }
+
+func TestSearchDashboards(t *testing.T) {
+	IsUnitTest(t)
+
+	// Five dashboards, served in pages of the requested size
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		results := []gapi.FolderDashboardSearchResponse{}
+		for i := (page - 1) * limit; i < page*limit && i < 5; i++ {
+			results = append(results, gapi.FolderDashboardSearchResponse{UID: strconv.Itoa(i)})
+		}
+		json.NewEncoder(w).Encode(results)
+	}))
+	defer server.Close()
+	client, err := gapi.New(server.URL, gapi.Config{Client: server.Client()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		limit    int
+		pageSize int
+		expected int
+	}{
+		{name: "all pages", limit: 0, pageSize: 2, expected: 5},
+		{name: "one by one", limit: 0, pageSize: 1, expected: 5},
+		{name: "limit across pages", limit: 3, pageSize: 2, expected: 3},
+		{name: "limit below the page size", limit: 2, pageSize: 1000, expected: 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			results, err := searchDashboards(client, url.Values{"type": {"dash-db"}}, tc.limit, tc.pageSize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(results) != tc.expected {
+				t.Fatalf("expected %d results, got %d", tc.expected, len(results))
+			}
+			for i, r := range results {
+				if r.UID != strconv.Itoa(i) {
+					t.Errorf("expected the results in order, got %s at %d", r.UID, i)
+				}
+			}
+		})
+	}
+}
@@ -22,6 +22,8 @@ func DatasourceLibraryPanel() *schema.Resource {
 				Optional:    true,
 				Description: "The unique identifier (UID) of the library panel.",
 			},
+			"ignore_paths": nil,
+			"on_delete":    nil,
 		}),
 	}
 }
@@ -41,7 +43,5 @@ func dataSourceLibraryPanelRead(ctx context.Context, d *schema.ResourceData, met
 	}
 
 	d.SetId(uid)
-	ReadLibraryPanel(ctx, d, meta)
-
-	return nil
+	return ReadLibraryPanel(ctx, d, meta)
 }
@@ -1,10 +1,15 @@
 package grafana
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func TestAccDatasourceLibraryPanel(t *testing.T) {
@@ -40,3 +45,37 @@ func TestAccDatasourceLibraryPanel(t *testing.T) {
 		},
 	})
 }
+
+func TestDatasourceLibraryPanelRead(t *testing.T) {
+	IsUnitTest(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/library-elements/my-panel":
+			fmt.Fprint(w, `{"result": {"uid": "my-panel", "name": "My Panel", "type": "text", "model": {"title": "My Panel", "type": "text", "options": {"content": "hello"}}}}`)
+		case "/api/library-elements/my-panel/connections":
+			fmt.Fprint(w, `{"result": [{"connectionId": 3}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	gapiClient, err := gapi.New(server.URL, gapi.Config{Client: server.Client()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, DatasourceLibraryPanel().Schema, map[string]interface{}{"uid": "my-panel"})
+	if diags := dataSourceLibraryPanelRead(context.Background(), d, &client{gapi: gapiClient}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "my-panel" || d.Get("name") != "My Panel" {
+		t.Errorf("expected the panel to be read, got ID %q and name %q", d.Id(), d.Get("name"))
+	}
+	if model := d.Get("model_json").(string); model == "" {
+		t.Errorf("expected the model to be read")
+	}
+	if ids := d.Get("dashboard_ids").([]interface{}); len(ids) != 1 || ids[0] != 3 {
+		t.Errorf("expected the connected dashboard to be read, got %v", ids)
+	}
+}
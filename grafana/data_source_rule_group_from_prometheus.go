@@ -0,0 +1,492 @@
+package grafana
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"gopkg.in/yaml.v3"
+)
+
+// The time range of the queries converted from Prometheus rules. They are instant queries, this only bounds the lookback.
+const prometheusRuleQueryTimeRange = 600
+
+var (
+	prometheusDurationRegexp   = regexp.MustCompile(`^(?:(\d+)y)?(?:(\d+)w)?(?:(\d+)d)?(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s)?(?:(\d+)ms)?$`)
+	prometheusDurationUnits    = []time.Duration{365 * 24 * time.Hour, 7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second, time.Millisecond}
+	prometheusDurationSuffixes = []string{"y", "w", "d", "h", "m", "s", "ms"}
+
+	prometheusComparisonRegexp = regexp.MustCompile(`==|!=|>=|<=|>|<`)
+	prometheusSetOperatorRegex = regexp.MustCompile(`(?i)\b(and|or|unless)\b`)
+)
+
+type prometheusRuleFile struct {
+	Groups []prometheusRuleGroup `yaml:"groups"`
+}
+
+type prometheusRuleGroup struct {
+	Name     string           `yaml:"name"`
+	Interval string           `yaml:"interval"`
+	Limit    int              `yaml:"limit"`
+	Rules    []prometheusRule `yaml:"rules"`
+}
+
+type prometheusRule struct {
+	Record        string            `yaml:"record"`
+	Alert         string            `yaml:"alert"`
+	Expr          string            `yaml:"expr"`
+	For           string            `yaml:"for"`
+	KeepFiringFor string            `yaml:"keep_firing_for"`
+	Labels        map[string]string `yaml:"labels"`
+	Annotations   map[string]string `yaml:"annotations"`
+}
+
+func DatasourceRuleGroupFromPrometheus() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Converts Prometheus rule groups, in the YAML format of Prometheus and Mimir rule files, to the ` + "`rule`" + ` blocks of the ` + "`grafana_rule_group`" + ` resource.
+This data source does not make any call to the Grafana API.
+
+Each rule queries ` + "`datasource_uid`" + ` with its ` + "`expr`" + ` in stage ` + "`A`" + `, reduced to its last value in stage ` + "`B`" + `.
+When the expression of an alerting rule ends with a comparison to a number, such as ` + "`rate(errors[5m]) > 0.1`" + `, the comparison
+becomes a threshold expression in stage ` + "`C`" + ` and the query is the left-hand side. Otherwise, stage ` + "`C`" + ` fires for every
+series returned by the query, like Prometheus does. Recording rules write stage ` + "`A`" + ` to the recorded metric, which requires Grafana 11 or later.
+
+Since rule names must be unique in a folder, rules with the same name in the document get a ` + "` (2)`" + `, ` + "` (3)`" + `... suffix.
+Labels and annotations are kept as-is, Grafana supports the ` + "`$labels`" + ` and ` + "`$value`" + ` variables of Prometheus templates.
+
+* [Prometheus alerting rules](https://prometheus.io/docs/prometheus/latest/configuration/alerting_rules/)
+* [Grafana-managed alert rules](https://grafana.com/docs/grafana/latest/alerting/alerting-rules/create-grafana-managed-rule/)
+`,
+		ReadContext: dataSourceRuleGroupFromPrometheusRead,
+		Schema: map[string]*schema.Schema{
+			"prometheus_rules_yaml": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The Prometheus rule file to convert, with a top-level `groups` list.",
+			},
+			"datasource_uid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The UID of the Prometheus (or Mimir) data source queried by the rules.",
+			},
+			"interval_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The evaluation interval of the groups that don't set an `interval`, in seconds.",
+			},
+			"no_data_state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "OK",
+				ValidateFunc: validation.StringInSlice([]string{"OK", "NoData", "Alerting"}, false),
+				Description:  "The `no_data_state` of the alerting rules. Defaults to `OK`, since Prometheus rules don't fire when their query returns nothing.",
+			},
+			"exec_err_state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Error",
+				ValidateFunc: validation.StringInSlice([]string{"OK", "Error", "Alerting"}, false),
+				Description:  "The `exec_err_state` of the alerting rules.",
+			},
+			"groups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The converted rule groups, in the order of the document.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the group.",
+						},
+						"interval_seconds": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The evaluation interval of the group, in seconds.",
+						},
+						"rule": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The rules of the group, with the attributes of the `rule` blocks of `grafana_rule_group`. `condition`, `for`, `no_data_state` and `exec_err_state` are empty for recording rules, which have a `record` block instead.",
+							Elem:        prometheusConvertedRuleSchema(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func prometheusConvertedRuleSchema() *schema.Resource {
+	computedString := func(description string) *schema.Schema {
+		return &schema.Schema{Type: schema.TypeString, Computed: true, Description: description}
+	}
+	computedMap := func(description string) *schema.Schema {
+		return &schema.Schema{Type: schema.TypeMap, Computed: true, Description: description, Elem: &schema.Schema{Type: schema.TypeString}}
+	}
+
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":           computedString("The name of the rule: its `alert`, or its `record` for recording rules."),
+			"condition":      computedString("The `ref_id` of the stage used as the alert condition."),
+			"for":            computedString("The pending period of the rule."),
+			"no_data_state":  computedString("The state of the rule when its query returns no data."),
+			"exec_err_state": computedString("The state of the rule when its query fails."),
+			"labels":         computedMap("The labels of the rule."),
+			"annotations":    computedMap("The annotations of the rule."),
+			"record": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The metric written by recording rules.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"metric": computedString("The name of the recorded metric."),
+						"from":   computedString("The `ref_id` of the stage written to the metric."),
+					},
+				},
+			},
+			"data": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The stages of the rule.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ref_id":         computedString("The identifier of the stage."),
+						"datasource_uid": computedString("The UID of the data source queried by the stage, or `-100` for expression stages."),
+						"query_type":     computedString("The type of query of the stage."),
+						"model":          computedString("The JSON model of the stage."),
+						"relative_time_range": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The time range of the stage.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"from": {Type: schema.TypeInt, Computed: true, Description: "The number of seconds in the past at which the time range begins."},
+									"to":   {Type: schema.TypeInt, Computed: true, Description: "The number of seconds in the past at which the time range ends."},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRuleGroupFromPrometheusRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	converter := prometheusRuleConverter{
+		datasourceUID:   d.Get("datasource_uid").(string),
+		defaultInterval: d.Get("interval_seconds").(int),
+		noDataState:     d.Get("no_data_state").(string),
+		execErrState:    d.Get("exec_err_state").(string),
+		names:           map[string]int{},
+	}
+	groups, err := converter.convert(d.Get("prometheus_rules_yaml").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	groupsJSON, err := json.Marshal(groups)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	hash := sha256.Sum256(groupsJSON)
+	d.SetId(fmt.Sprintf("%x", hash[:]))
+	if err := d.Set("groups", groups); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	for _, warning := range converter.warnings {
+		diags = append(diags, diag.Diagnostic{Severity: diag.Warning, Summary: warning})
+	}
+	return diags
+}
+
+// prometheusRuleConverter converts the groups of a Prometheus rule file. It keeps the rule names across groups,
+// to make them unique.
+type prometheusRuleConverter struct {
+	datasourceUID   string
+	defaultInterval int
+	noDataState     string
+	execErrState    string
+
+	names    map[string]int
+	warnings []string
+}
+
+func (c *prometheusRuleConverter) convert(rulesYAML string) ([]interface{}, error) {
+	var file prometheusRuleFile
+	if err := yaml.Unmarshal([]byte(rulesYAML), &file); err != nil {
+		return nil, fmt.Errorf("error parsing the Prometheus rules: %s", err)
+	}
+
+	groupNames := map[string]bool{}
+	groups := make([]interface{}, 0, len(file.Groups))
+	for _, group := range file.Groups {
+		if group.Name == "" {
+			return nil, fmt.Errorf("all the rule groups must have a name")
+		}
+		if groupNames[group.Name] {
+			return nil, fmt.Errorf("rule group %q is defined more than once", group.Name)
+		}
+		groupNames[group.Name] = true
+
+		interval := c.defaultInterval
+		if group.Interval != "" {
+			duration, err := parsePrometheusDuration(group.Interval)
+			if err != nil || duration < time.Second || duration%time.Second != 0 {
+				return nil, fmt.Errorf("rule group %q: interval %q must be a whole number of seconds", group.Name, group.Interval)
+			}
+			interval = int(duration / time.Second)
+		}
+		if group.Limit != 0 {
+			c.warnings = append(c.warnings, fmt.Sprintf("rule group %q: `limit` is not supported by Grafana, it is ignored", group.Name))
+		}
+
+		rules := make([]interface{}, 0, len(group.Rules))
+		for i, rule := range group.Rules {
+			converted, err := c.convertRule(rule)
+			if err != nil {
+				return nil, fmt.Errorf("rule group %q, rule %d: %s", group.Name, i+1, err)
+			}
+			rules = append(rules, converted)
+		}
+
+		groups = append(groups, map[string]interface{}{
+			"name":             group.Name,
+			"interval_seconds": interval,
+			"rule":             rules,
+		})
+	}
+	return groups, nil
+}
+
+func (c *prometheusRuleConverter) convertRule(rule prometheusRule) (map[string]interface{}, error) {
+	if (rule.Alert == "") == (rule.Record == "") {
+		return nil, fmt.Errorf("one of `alert` or `record` must be set")
+	}
+	expr := strings.TrimSpace(rule.Expr)
+	if expr == "" {
+		return nil, fmt.Errorf("`expr` must be set")
+	}
+
+	converted := map[string]interface{}{
+		"condition":      "",
+		"for":            "",
+		"no_data_state":  "",
+		"exec_err_state": "",
+		"labels":         stringMapToInterface(rule.Labels),
+		"annotations":    stringMapToInterface(rule.Annotations),
+		"record":         []interface{}{},
+	}
+	if rule.Record != "" {
+		if rule.For != "" || len(rule.Annotations) > 0 {
+			return nil, fmt.Errorf("recording rule %q: `for` and `annotations` can't be set on recording rules", rule.Record)
+		}
+		converted["name"] = c.uniqueName(rule.Record)
+		converted["record"] = []interface{}{map[string]interface{}{"metric": rule.Record, "from": "A"}}
+		converted["data"] = []interface{}{c.queryStage(expr)}
+		return converted, nil
+	}
+
+	pendingPeriod := time.Duration(0)
+	if rule.For != "" {
+		var err error
+		if pendingPeriod, err = parsePrometheusDuration(rule.For); err != nil {
+			return nil, fmt.Errorf("alert %q: %s", rule.Alert, err)
+		}
+	}
+	if rule.KeepFiringFor != "" {
+		c.warnings = append(c.warnings, fmt.Sprintf("alert %q: `keep_firing_for` is not supported by Grafana, it is ignored", rule.Alert))
+	}
+
+	// Without a threshold, all the series returned by the query are firing
+	conditionExpression := "math"
+	condition := map[string]interface{}{"expression": "is_number($B) || is_nan($B) || is_inf($B)"}
+	if query, evaluator, threshold, ok := splitPrometheusThreshold(expr); ok {
+		expr = query
+		conditionExpression = "threshold"
+		condition = map[string]interface{}{
+			"expression": "B",
+			"evaluator":  []interface{}{map[string]interface{}{"type": evaluator, "params": []interface{}{threshold}}},
+		}
+	}
+	reduce := map[string]interface{}{"expression": "A", "reducer": "last", "mode": ""}
+
+	converted["name"] = c.uniqueName(rule.Alert)
+	converted["condition"] = "C"
+	converted["for"] = formatPrometheusDuration(pendingPeriod)
+	converted["no_data_state"] = c.noDataState
+	converted["exec_err_state"] = c.execErrState
+	converted["data"] = []interface{}{
+		c.queryStage(expr),
+		expressionStage("B", "reduce", reduce),
+		expressionStage("C", conditionExpression, condition),
+	}
+	return converted, nil
+}
+
+func (c *prometheusRuleConverter) queryStage(expr string) map[string]interface{} {
+	model := map[string]interface{}{
+		"refId":   "A",
+		"expr":    expr,
+		"instant": true,
+		"range":   false,
+	}
+	return map[string]interface{}{
+		"ref_id":              "A",
+		"datasource_uid":      c.datasourceUID,
+		"query_type":          "",
+		"model":               prometheusRuleModelJSON(model),
+		"relative_time_range": []interface{}{map[string]interface{}{"from": prometheusRuleQueryTimeRange, "to": 0}},
+	}
+}
+
+func (c *prometheusRuleConverter) uniqueName(name string) string {
+	c.names[name]++
+	if count := c.names[name]; count > 1 {
+		return fmt.Sprintf("%s (%d)", name, count)
+	}
+	return name
+}
+
+// expressionStage returns a `data` stage with the model of an expression block.
+func expressionStage(refID, name string, block map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"ref_id":              refID,
+		"datasource_uid":      expressionDatasourceUID,
+		"query_type":          "",
+		"model":               prometheusRuleModelJSON(compileRuleExpression(name, block, refID)),
+		"relative_time_range": []interface{}{map[string]interface{}{"from": 0, "to": 0}},
+	}
+}
+
+// prometheusRuleModelJSON returns the JSON of a stage model. The comparison operators of the expressions are not escaped,
+// so that the models are readable in plans.
+func prometheusRuleModelJSON(model map[string]interface{}) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(model)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// splitPrometheusThreshold splits an expression of the form `<query> > <number>` (or `<`) into the query, the threshold
+// evaluator type and the number. It returns false for any other expression, such as comparisons with the `bool` modifier,
+// comparisons between vectors, or comparisons combined with `and`, `or` or `unless`.
+func splitPrometheusThreshold(expr string) (string, string, float64, bool) {
+	masked := maskNestedPromQL(expr)
+	comparisons := prometheusComparisonRegexp.FindAllStringIndex(masked, -1)
+	if len(comparisons) != 1 {
+		return "", "", 0, false
+	}
+	start, end := comparisons[0][0], comparisons[0][1]
+
+	evaluator := map[string]string{">": "gt", "<": "lt"}[expr[start:end]]
+	query := strings.TrimSpace(expr[:start])
+	threshold, err := strconv.ParseFloat(strings.TrimSpace(masked[end:]), 64)
+	if evaluator == "" || query == "" || err != nil || math.IsInf(threshold, 0) || math.IsNaN(threshold) {
+		return "", "", 0, false
+	}
+	if prometheusSetOperatorRegex.MatchString(masked[:start]) {
+		return "", "", 0, false
+	}
+	return query, evaluator, threshold, true
+}
+
+// maskNestedPromQL replaces the contents of the brackets, strings and comments of a PromQL expression with spaces,
+// so that only its top-level operators are left. The offsets of the expression are kept.
+func maskNestedPromQL(expr string) string {
+	masked := []byte(expr)
+	depth := 0
+	var quote byte
+	for i := 0; i < len(masked); i++ {
+		char := masked[i]
+		switch {
+		case quote != 0:
+			if char == '\\' && quote != '`' && i+1 < len(masked) {
+				masked[i] = ' '
+				i++
+			} else if char == quote {
+				quote = 0
+			}
+			masked[i] = ' '
+		case char == '"' || char == '\'' || char == '`':
+			quote = char
+			masked[i] = ' '
+		case char == '#':
+			for ; i < len(masked) && masked[i] != '\n'; i++ {
+				masked[i] = ' '
+			}
+		case char == '(' || char == '[' || char == '{':
+			depth++
+			masked[i] = ' '
+		case char == ')' || char == ']' || char == '}':
+			depth--
+			masked[i] = ' '
+		case depth > 0:
+			masked[i] = ' '
+		}
+	}
+	return string(masked)
+}
+
+// parsePrometheusDuration parses a duration in the format of Prometheus, such as `1h30m`.
+func parsePrometheusDuration(s string) (time.Duration, error) {
+	if s == "0" {
+		return 0, nil
+	}
+	matches := prometheusDurationRegexp.FindStringSubmatch(s)
+	if s == "" || matches == nil {
+		return 0, fmt.Errorf("%q is not a valid duration", s)
+	}
+	var duration time.Duration
+	for i, unit := range prometheusDurationUnits {
+		if matches[i+1] == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(matches[i+1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a valid duration: %s", s, err)
+		}
+		duration += time.Duration(n) * unit
+	}
+	return duration, nil
+}
+
+// formatPrometheusDuration formats a duration the way Grafana returns it, such as `1h30m`.
+func formatPrometheusDuration(duration time.Duration) string {
+	if duration == 0 {
+		return "0s"
+	}
+	var formatted strings.Builder
+	for i, unit := range prometheusDurationUnits {
+		if n := duration / unit; n > 0 {
+			fmt.Fprintf(&formatted, "%d%s", n, prometheusDurationSuffixes[i])
+			duration -= n * unit
+		}
+	}
+	return formatted.String()
+}
+
+func stringMapToInterface(m map[string]string) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
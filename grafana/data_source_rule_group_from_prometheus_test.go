@@ -0,0 +1,197 @@
+package grafana
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccDatasourceRuleGroupFromPrometheus(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=9.1.0")
+
+	var group gapi.RuleGroup
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAlertRuleCheckDestroy(&group),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "data-sources/grafana_rule_group_from_prometheus/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testRuleGroupCheckExists("grafana_rule_group.migrated[\"node\"]", &group),
+					resource.TestCheckResourceAttr("data.grafana_rule_group_from_prometheus.node", "groups.#", "1"),
+					resource.TestCheckResourceAttr("grafana_rule_group.migrated[\"node\"]", "interval_seconds", "120"),
+					resource.TestCheckResourceAttr("grafana_rule_group.migrated[\"node\"]", "rule.#", "2"),
+					resource.TestCheckResourceAttr("grafana_rule_group.migrated[\"node\"]", "rule.0.name", "HighCPU"),
+					resource.TestCheckResourceAttr("grafana_rule_group.migrated[\"node\"]", "rule.0.for", "10m"),
+					resource.TestCheckResourceAttr("grafana_rule_group.migrated[\"node\"]", "rule.0.condition", "C"),
+					resource.TestCheckResourceAttr("grafana_rule_group.migrated[\"node\"]", "rule.0.labels.severity", "warning"),
+					resource.TestCheckResourceAttr("grafana_rule_group.migrated[\"node\"]", "rule.1.name", "InstanceDown"),
+					resource.TestCheckResourceAttr("grafana_rule_group.migrated[\"node\"]", "rule.1.no_data_state", "OK"),
+				),
+			},
+		},
+	})
+}
+
+func TestDatasourceRuleGroupFromPrometheusRead(t *testing.T) {
+	IsUnitTest(t)
+
+	d := schema.TestResourceDataRaw(t, DatasourceRuleGroupFromPrometheus().Schema, map[string]interface{}{
+		"datasource_uid": "prom",
+		"prometheus_rules_yaml": `
+groups:
+  - name: api
+    interval: 30s
+    rules:
+      - record: job:errors:rate5m
+        expr: sum by (job) (rate(errors_total[5m]))
+        labels:
+          team: api
+      - alert: HighErrorRate
+        expr: job:errors:rate5m{job="api"} > 0.5
+        for: 300s
+        keep_firing_for: 5m
+        labels:
+          severity: page
+        annotations:
+          summary: "{{ $labels.job }} has {{ $value }} errors"
+      - alert: HighErrorRate
+        expr: job:errors:rate5m > on(job) job:requests:rate5m
+  - name: default-interval
+    rules:
+      - alert: Up
+        expr: up == 1
+`,
+	})
+	diags := DatasourceRuleGroupFromPrometheus().ReadContext(context.Background(), d, nil)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(diags) != 1 || diags[0].Summary != "alert \"HighErrorRate\": `keep_firing_for` is not supported by Grafana, it is ignored" {
+		t.Errorf("expected a warning for keep_firing_for, got %v", diags)
+	}
+
+	expected := map[string]string{
+		"groups.#":                  "2",
+		"groups.0.name":             "api",
+		"groups.0.interval_seconds": "30",
+		"groups.0.rule.#":           "3",
+
+		"groups.0.rule.0.name":                  "job:errors:rate5m",
+		"groups.0.rule.0.condition":             "",
+		"groups.0.rule.0.record.0.metric":       "job:errors:rate5m",
+		"groups.0.rule.0.record.0.from":         "A",
+		"groups.0.rule.0.labels.team":           "api",
+		"groups.0.rule.0.data.#":                "1",
+		"groups.0.rule.0.data.0.datasource_uid": "prom",
+		"groups.0.rule.0.data.0.model":          `{"expr":"sum by (job) (rate(errors_total[5m]))","instant":true,"range":false,"refId":"A"}`,
+
+		"groups.0.rule.1.name":                              "HighErrorRate",
+		"groups.0.rule.1.condition":                         "C",
+		"groups.0.rule.1.for":                               "5m",
+		"groups.0.rule.1.no_data_state":                     "OK",
+		"groups.0.rule.1.exec_err_state":                    "Error",
+		"groups.0.rule.1.labels.severity":                   "page",
+		"groups.0.rule.1.annotations.summary":               "{{ $labels.job }} has {{ $value }} errors",
+		"groups.0.rule.1.record.#":                          "0",
+		"groups.0.rule.1.data.#":                            "3",
+		"groups.0.rule.1.data.0.relative_time_range.0.from": "600",
+		"groups.0.rule.1.data.0.model":                      `{"expr":"job:errors:rate5m{job=\"api\"}","instant":true,"range":false,"refId":"A"}`,
+		"groups.0.rule.1.data.1.datasource_uid":             "-100",
+		"groups.0.rule.1.data.1.model":                      `{"datasource":{"type":"__expr__","uid":"-100"},"expression":"A","reducer":"last","refId":"B","type":"reduce"}`,
+		"groups.0.rule.1.data.2.relative_time_range.0.from": "0",
+		"groups.0.rule.1.data.2.model":                      `{"conditions":[{"evaluator":{"params":[0.5],"type":"gt"}}],"datasource":{"type":"__expr__","uid":"-100"},"expression":"B","refId":"C","type":"threshold"}`,
+
+		"groups.0.rule.2.name":         "HighErrorRate (2)",
+		"groups.0.rule.2.for":          "0s",
+		"groups.0.rule.2.data.0.model": `{"expr":"job:errors:rate5m > on(job) job:requests:rate5m","instant":true,"range":false,"refId":"A"}`,
+		"groups.0.rule.2.data.2.model": `{"datasource":{"type":"__expr__","uid":"-100"},"expression":"is_number($B) || is_nan($B) || is_inf($B)","refId":"C","type":"math"}`,
+
+		"groups.1.name":                "default-interval",
+		"groups.1.interval_seconds":    "60",
+		"groups.1.rule.0.data.0.model": `{"expr":"up == 1","instant":true,"range":false,"refId":"A"}`,
+	}
+	state := d.State().Attributes
+	for key, value := range expected {
+		if state[key] != value {
+			t.Errorf("%s: expected %q, got %q", key, value, state[key])
+		}
+	}
+
+	for _, invalid := range []struct{ yaml, err string }{
+		{"groups: [{rules: []}]", "all the rule groups must have a name"},
+		{"groups: [{name: a}, {name: a}]", `rule group "a" is defined more than once`},
+		{"groups: [{name: a, interval: 1500ms}]", `rule group "a": interval "1500ms" must be a whole number of seconds`},
+		{"groups: [{name: a, rules: [{expr: up}]}]", `rule group "a", rule 1: one of ` + "`alert` or `record`" + ` must be set`},
+		{"groups: [{name: a, rules: [{alert: b, expr: up, for: 5 minutes}]}]", `rule group "a", rule 1: alert "b": "5 minutes" is not a valid duration`},
+	} {
+		d := schema.TestResourceDataRaw(t, DatasourceRuleGroupFromPrometheus().Schema, map[string]interface{}{
+			"datasource_uid":        "prom",
+			"prometheus_rules_yaml": invalid.yaml,
+		})
+		diags := DatasourceRuleGroupFromPrometheus().ReadContext(context.Background(), d, nil)
+		if !diags.HasError() || diags[0].Summary != invalid.err {
+			t.Errorf("expected error %q, got %v", invalid.err, diags)
+		}
+	}
+}
+
+func TestSplitPrometheusThreshold(t *testing.T) {
+	IsUnitTest(t)
+
+	for _, tc := range []struct {
+		expr, query, evaluator string
+		threshold              float64
+	}{
+		{expr: "up < 1", query: "up", evaluator: "lt", threshold: 1},
+		{expr: `sum(rate(x{a=">"}[5m])) by (job) > 1e3`, query: `sum(rate(x{a=">"}[5m])) by (job)`, evaluator: "gt", threshold: 1000},
+		{expr: "(a > 1) > -2.5 # the ratio", query: "(a > 1)", evaluator: "gt", threshold: -2.5},
+		{expr: "up > bool 1"},
+		{expr: "up >= 1"},
+		{expr: "a > b"},
+		{expr: "1 < up"},
+		{expr: "b and a > 1"},
+		{expr: "a > 1 and b"},
+		{expr: "a > Inf"},
+	} {
+		query, evaluator, threshold, ok := splitPrometheusThreshold(tc.expr)
+		if ok != (tc.evaluator != "") || query != tc.query || evaluator != tc.evaluator || threshold != tc.threshold {
+			t.Errorf("%s: unexpected split %q, %q, %v, %v", tc.expr, query, evaluator, threshold, ok)
+		}
+	}
+}
+
+func TestPrometheusDuration(t *testing.T) {
+	IsUnitTest(t)
+
+	for s, expected := range map[string]string{
+		"0":      "0s",
+		"90s":    "1m30s",
+		"1h30m":  "1h30m",
+		"14d":    "2w",
+		"1y":     "1y",
+		"1500ms": "1s500ms",
+	} {
+		duration, err := parsePrometheusDuration(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if formatted := formatPrometheusDuration(duration); formatted != expected {
+			t.Errorf("%s: expected %s, got %s", s, expected, formatted)
+		}
+	}
+	for _, invalid := range []string{"", "5", "1m1h", "-1s", "1.5h"} {
+		if _, err := parsePrometheusDuration(invalid); err == nil {
+			t.Errorf("%q: expected an error", invalid)
+		}
+	}
+	if duration, _ := parsePrometheusDuration("1w2d"); duration != 9*24*time.Hour {
+		t.Errorf("unexpected duration %s", duration)
+	}
+}
@@ -0,0 +1,593 @@
+package grafana
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+// grafanaRequest performs a request against the Grafana HTTP API for endpoints that are not covered by the Grafana API client.
+// It uses the same configuration as the client (authentication, org, HTTP headers and retries) and returns errors in the
+// same `status: <code>, body: <body>` format, so that callers can handle both kinds of errors the same way.
+func (c *client) grafanaRequest(method, requestPath string, query url.Values, body interface{}, responseStruct interface{}) error {
+	return c.grafanaRequestWithHeaders(method, requestPath, query, nil, body, responseStruct)
+}
+
+// grafanaRequestWithHeaders is grafanaRequest, with HTTP headers added to the ones of the configuration.
+func (c *client) grafanaRequestWithHeaders(method, requestPath string, query url.Values, headers map[string]string, body interface{}, responseStruct interface{}) error {
+	var bodyContents []byte
+	if body != nil {
+		var err error
+		if bodyContents, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	var (
+		resp         *http.Response
+		respContents []byte
+		err          error
+	)
+	for n := 0; n <= c.gapiConfig.NumRetries; n++ {
+		if n != 0 {
+			time.Sleep(time.Second * 5)
+		}
+
+		var req *http.Request
+		req, err = c.newGrafanaRequest(method, requestPath, query, headers, bodyContents)
+		if err != nil {
+			return err
+		}
+
+		resp, err = c.gapiConfig.Client.Do(req)
+		if err != nil {
+			continue
+		}
+
+		respContents, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			continue
+		}
+
+		// Only retry server errors and rate limiting
+		if resp.StatusCode < http.StatusInternalServerError && resp.StatusCode != http.StatusTooManyRequests {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("status: %d, body: %v", resp.StatusCode, string(respContents))
+	}
+
+	if responseStruct == nil || len(respContents) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(respContents, responseStruct)
+}
+
+func (c *client) newGrafanaRequest(method, requestPath string, query url.Values, headers map[string]string, body []byte) (*http.Request, error) {
+	u, err := url.Parse(c.gapiURL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, requestPath)
+	u.RawQuery = query.Encode()
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, u.String(), bodyReader)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := c.gapiConfig
+	if cfg.APIKey != "" {
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", cfg.APIKey))
+	} else {
+		if cfg.BasicAuth != nil {
+			password, _ := cfg.BasicAuth.Password()
+			req.SetBasicAuth(cfg.BasicAuth.Username(), password)
+		}
+		if cfg.OrgID != 0 {
+			req.Header.Add("X-Grafana-Org-Id", strconv.FormatInt(cfg.OrgID, 10))
+		}
+	}
+	for k, v := range cfg.HTTPHeaders {
+		req.Header.Add(k, v)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	return req, nil
+}
+
+// resourcePermission is a permission, as returned by the `/api/access-control/:resource/:uid` endpoint.
+type resourcePermission struct {
+	ID               int64  `json:"id"`
+	UserID           int64  `json:"userId,omitempty"`
+	TeamID           int64  `json:"teamId,omitempty"`
+	BuiltInRole      string `json:"builtInRole,omitempty"`
+	IsManaged        bool   `json:"isManaged"`
+	IsInherited      bool   `json:"isInherited"`
+	IsServiceAccount bool   `json:"isServiceAccount"`
+	Permission       string `json:"permission"`
+}
+
+func (c *client) listResourcePermissions(resourceType, uid string) ([]*resourcePermission, error) {
+	permissions := []*resourcePermission{}
+	err := c.grafanaRequest("GET", fmt.Sprintf("/api/access-control/%s/%s", resourceType, uid), nil, nil, &permissions)
+	return permissions, err
+}
+
+// setResourcePermission sets the permission of a single principal on a resource. An empty permission removes the grant.
+func (c *client) setResourcePermission(resourceType, uid, principalPath, permission string) error {
+	body := map[string]string{"permission": permission}
+	return c.grafanaRequest("POST", fmt.Sprintf("/api/access-control/%s/%s/%s", resourceType, uid, principalPath), nil, body, nil)
+}
+
+// resourcePermissionAssignment is a permission to set on a resource through the `/api/access-control/:resource/:uid` endpoint.
+type resourcePermissionAssignment struct {
+	UserID      int64  `json:"userId,omitempty"`
+	TeamID      int64  `json:"teamId,omitempty"`
+	BuiltInRole string `json:"builtInRole,omitempty"`
+	Permission  string `json:"permission"`
+}
+
+func (a resourcePermissionAssignment) sameTarget(p *resourcePermission) bool {
+	return a.UserID == p.UserID && a.TeamID == p.TeamID && a.BuiltInRole == p.BuiltInRole
+}
+
+// setResourcePermissions sets the permissions of the given principals on a resource. Other principals are left untouched.
+func (c *client) setResourcePermissions(resourceType, uid string, assignments []resourcePermissionAssignment) error {
+	body := map[string]interface{}{"permissions": assignments}
+	return c.grafanaRequest("POST", fmt.Sprintf("/api/access-control/%s/%s", resourceType, uid), nil, body, nil)
+}
+
+// replaceResourcePermissions sets the permissions of a resource to the given assignments.
+// Managed permissions of principals that are not in the assignments are removed.
+func (c *client) replaceResourcePermissions(resourceType, uid string, assignments []resourcePermissionAssignment) error {
+	current, err := c.listResourcePermissions(resourceType, uid)
+	if err != nil {
+		return err
+	}
+
+	all := append([]resourcePermissionAssignment{}, assignments...)
+currentLoop:
+	for _, p := range current {
+		if !p.IsManaged || p.IsInherited {
+			continue
+		}
+		for _, a := range assignments {
+			if a.sameTarget(p) {
+				continue currentLoop
+			}
+		}
+		all = append(all, resourcePermissionAssignment{UserID: p.UserID, TeamID: p.TeamID, BuiltInRole: p.BuiltInRole, Permission: ""})
+	}
+
+	if len(all) == 0 {
+		return nil
+	}
+	return c.setResourcePermissions(resourceType, uid, all)
+}
+
+// resourcePermissionsSupported returns whether the access control API can be used to manage the permissions of the given resource type.
+// This is the case when Grafana has RBAC (Grafana 9+ for folders and dashboards), older versions only support the legacy permissions API.
+// Only a 404 means that the API doesn't exist, other errors are returned so that the legacy API isn't used by mistake, which would
+// overwrite the permissions that it can't represent. The result is cached for the client.
+func (c *client) resourcePermissionsSupported(resourceType string) (bool, error) {
+	c.resourcePermissionsSupportMutex.Lock()
+	defer c.resourcePermissionsSupportMutex.Unlock()
+	if supported, ok := c.resourcePermissionsSupport[resourceType]; ok {
+		return supported, nil
+	}
+
+	err := c.grafanaRequest("GET", fmt.Sprintf("/api/access-control/%s/description", resourceType), nil, nil, nil)
+	if err != nil && !strings.HasPrefix(err.Error(), "status: 404") {
+		return false, fmt.Errorf("error checking whether the access control API supports %s: %w", resourceType, err)
+	}
+	if c.resourcePermissionsSupport == nil {
+		c.resourcePermissionsSupport = map[string]bool{}
+	}
+	c.resourcePermissionsSupport[resourceType] = err == nil
+	return err == nil, nil
+}
+
+// dashboardVersion is a version of a dashboard, as returned by the `/api/dashboards/id/:id/versions` endpoint.
+type dashboardVersion struct {
+	ID            int64     `json:"id"`
+	DashboardID   int64     `json:"dashboardId"`
+	ParentVersion int64     `json:"parentVersion"`
+	RestoredFrom  int64     `json:"restoredFrom"`
+	Version       int64     `json:"version"`
+	Created       time.Time `json:"created"`
+	CreatedBy     string    `json:"createdBy"`
+	Message       string    `json:"message"`
+}
+
+// dashboardVersions lists the versions of a dashboard, from the most recent. A limit of 0 uses the default of the API.
+func (c *client) dashboardVersions(dashboardID int64, limit int) ([]*dashboardVersion, error) {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	// Recent Grafana versions wrap the versions in an object
+	var raw json.RawMessage
+	if err := c.grafanaRequest("GET", fmt.Sprintf("/api/dashboards/id/%d/versions", dashboardID), query, nil, &raw); err != nil {
+		return nil, err
+	}
+	versions := []*dashboardVersion{}
+	if len(raw) > 0 && raw[0] == '{' {
+		var wrapped struct {
+			Versions []*dashboardVersion `json:"versions"`
+		}
+		err := json.Unmarshal(raw, &wrapped)
+		return wrapped.Versions, err
+	}
+	err := json.Unmarshal(raw, &versions)
+	return versions, err
+}
+
+// restoreDashboardVersion restores a previous version of a dashboard. This creates a new version.
+func (c *client) restoreDashboardVersion(dashboardID, version int64) error {
+	body := map[string]int64{"version": version}
+	return c.grafanaRequest("POST", fmt.Sprintf("/api/dashboards/id/%d/restore", dashboardID), nil, body, nil)
+}
+
+// publicDashboard is the public sharing configuration of a dashboard (Grafana 10+).
+type publicDashboard struct {
+	UID                  string `json:"uid,omitempty"`
+	DashboardUID         string `json:"dashboardUid,omitempty"`
+	AccessToken          string `json:"accessToken,omitempty"`
+	IsEnabled            bool   `json:"isEnabled"`
+	TimeSelectionEnabled bool   `json:"timeSelectionEnabled"`
+	AnnotationsEnabled   bool   `json:"annotationsEnabled"`
+	Share                string `json:"share,omitempty"`
+}
+
+func (c *client) publicDashboard(dashboardUID string) (*publicDashboard, error) {
+	result := &publicDashboard{}
+	err := c.grafanaRequest("GET", fmt.Sprintf("/api/dashboards/uid/%s/public-dashboards", dashboardUID), nil, nil, result)
+	return result, err
+}
+
+func (c *client) newPublicDashboard(dashboardUID string, dashboard *publicDashboard) (*publicDashboard, error) {
+	result := &publicDashboard{}
+	err := c.grafanaRequest("POST", fmt.Sprintf("/api/dashboards/uid/%s/public-dashboards", dashboardUID), nil, dashboard, result)
+	return result, err
+}
+
+func (c *client) updatePublicDashboard(dashboardUID, uid string, dashboard *publicDashboard) (*publicDashboard, error) {
+	result := &publicDashboard{}
+	err := c.grafanaRequest("PATCH", fmt.Sprintf("/api/dashboards/uid/%s/public-dashboards/%s", dashboardUID, uid), nil, dashboard, result)
+	return result, err
+}
+
+func (c *client) deletePublicDashboard(dashboardUID, uid string) error {
+	return c.grafanaRequest("DELETE", fmt.Sprintf("/api/dashboards/uid/%s/public-dashboards/%s", dashboardUID, uid), nil, nil, nil)
+}
+
+// dashboardSnapshotRequest is the body of the `/api/snapshots` endpoint.
+type dashboardSnapshotRequest struct {
+	Dashboard map[string]interface{} `json:"dashboard"`
+	Name      string                 `json:"name,omitempty"`
+	Expires   int64                  `json:"expires,omitempty"`
+	External  bool                   `json:"external"`
+}
+
+// dashboardSnapshot is a created snapshot, as returned by the `/api/snapshots` endpoint.
+type dashboardSnapshot struct {
+	ID        int64  `json:"id"`
+	Key       string `json:"key"`
+	DeleteKey string `json:"deleteKey"`
+	URL       string `json:"url"`
+	DeleteURL string `json:"deleteUrl"`
+}
+
+func (c *client) newDashboardSnapshot(snapshot *dashboardSnapshotRequest) (*dashboardSnapshot, error) {
+	result := &dashboardSnapshot{}
+	err := c.grafanaRequest("POST", "/api/snapshots", nil, snapshot, result)
+	return result, err
+}
+
+// dashboardSnapshotExists returns whether a snapshot exists. Expired snapshots don't.
+func (c *client) dashboardSnapshotExists(key string) (bool, error) {
+	snapshot, err := c.getDashboardSnapshot(key)
+	return err == nil && snapshot != nil, err
+}
+
+// dashboardSnapshotModel is a snapshot as returned by `GET /api/snapshots/:key`.
+type dashboardSnapshotModel struct {
+	Dashboard map[string]interface{} `json:"dashboard"`
+	Meta      struct {
+		Created time.Time `json:"created"`
+		Expires time.Time `json:"expires"`
+	} `json:"meta"`
+}
+
+// dashboardSnapshotListItem is a snapshot as listed by the `/api/dashboard/snapshots` endpoint.
+type dashboardSnapshotListItem struct {
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	External bool   `json:"external"`
+}
+
+// getDashboardSnapshot returns a snapshot with its dashboard, or nil if it doesn't exist.
+func (c *client) getDashboardSnapshot(key string) (*dashboardSnapshotModel, error) {
+	result := &dashboardSnapshotModel{}
+	err := c.grafanaRequest("GET", "/api/snapshots/"+key, nil, nil, result)
+	if err != nil && strings.HasPrefix(err.Error(), "status: 404") {
+		return nil, nil
+	}
+	return result, err
+}
+
+func (c *client) dashboardSnapshots() ([]dashboardSnapshotListItem, error) {
+	var result []dashboardSnapshotListItem
+	err := c.grafanaRequest("GET", "/api/dashboard/snapshots", url.Values{"limit": {"1000"}}, nil, &result)
+	return result, err
+}
+
+func (c *client) deleteDashboardSnapshotWithDeleteKey(deleteKey string) error {
+	return c.grafanaRequest("GET", "/api/snapshots-delete/"+deleteKey, nil, nil, nil)
+}
+
+func (c *client) deleteDashboardSnapshot(key string) error {
+	return c.grafanaRequest("DELETE", "/api/snapshots/"+key, nil, nil, nil)
+}
+
+// dashboardImportInput is the value of an input (`__inputs` entry) of a dashboard, as sent to `/api/dashboards/import`.
+type dashboardImportInput struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	PluginID string `json:"pluginId,omitempty"`
+	Value    string `json:"value"`
+}
+
+type dashboardImportRequest struct {
+	Dashboard map[string]interface{} `json:"dashboard"`
+	Overwrite bool                   `json:"overwrite"`
+	Inputs    []dashboardImportInput `json:"inputs"`
+	FolderID  int64                  `json:"folderId"`
+}
+
+type dashboardImportResponse struct {
+	UID         string `json:"uid"`
+	Title       string `json:"title"`
+	DashboardID int64  `json:"dashboardId"`
+	ImportedURL string `json:"importedUrl"`
+}
+
+func (c *client) importDashboard(request *dashboardImportRequest) (*dashboardImportResponse, error) {
+	var response dashboardImportResponse
+	if err := c.grafanaRequest("POST", "/api/dashboards/import", nil, request, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// gnetDashboard downloads a revision of a community dashboard from the Grafana.com API.
+// The API is public, so the request is not authenticated.
+func (c *client) gnetDashboard(id, revision int64) (map[string]interface{}, error) {
+	u, err := url.Parse(c.cloudAPIURL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, "api", "dashboards", strconv.FormatInt(id, 10), "revisions", strconv.FormatInt(revision, 10), "download")
+
+	resp, err := cleanhttp.DefaultClient().Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respContents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("status: %d, body: %v", resp.StatusCode, string(respContents))
+	}
+
+	var dashboard map[string]interface{}
+	if err := json.Unmarshal(respContents, &dashboard); err != nil {
+		return nil, fmt.Errorf("error decoding dashboard %d revision %d: %w", id, revision, err)
+	}
+	return dashboard, nil
+}
+
+// grafanaPlugin is a plugin installed in Grafana, as returned by `/api/plugins`.
+type grafanaPlugin struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Info struct {
+		Version string `json:"version"`
+	} `json:"info"`
+}
+
+func (c *client) plugins() ([]grafanaPlugin, error) {
+	var plugins []grafanaPlugin
+	err := c.grafanaRequest("GET", "/api/plugins", url.Values{"embedded": {"0"}}, nil, &plugins)
+	return plugins, err
+}
+
+// disableProvenanceHeader makes the alerting provisioning API save resources without provenance, so that they can be
+// edited in the Grafana UI.
+const disableProvenanceHeader = "X-Disable-Provenance"
+
+func provisioningHeaders(disableProvenance bool) map[string]string {
+	if !disableProvenance {
+		return nil
+	}
+	return map[string]string{disableProvenanceHeader: "true"}
+}
+
+func (c *client) newContactPoint(p *gapi.ContactPoint, disableProvenance bool) (string, error) {
+	var created gapi.ContactPoint
+	if err := c.grafanaRequestWithHeaders("POST", "/api/v1/provisioning/contact-points", nil, provisioningHeaders(disableProvenance), p, &created); err != nil {
+		return "", err
+	}
+	return created.UID, nil
+}
+
+func (c *client) updateContactPoint(p *gapi.ContactPoint, disableProvenance bool) error {
+	return c.grafanaRequestWithHeaders("PUT", "/api/v1/provisioning/contact-points/"+p.UID, nil, provisioningHeaders(disableProvenance), p, nil)
+}
+
+func (c *client) newMuteTiming(mt *gapi.MuteTiming, disableProvenance bool) error {
+	return c.grafanaRequestWithHeaders("POST", "/api/v1/provisioning/mute-timings", nil, provisioningHeaders(disableProvenance), mt, nil)
+}
+
+func (c *client) updateMuteTiming(mt *gapi.MuteTiming, disableProvenance bool) error {
+	return c.grafanaRequestWithHeaders("PUT", "/api/v1/provisioning/mute-timings/"+mt.Name, nil, provisioningHeaders(disableProvenance), mt, nil)
+}
+
+func (c *client) setMessageTemplate(name, content string, disableProvenance bool) error {
+	body := map[string]string{"template": content}
+	return c.grafanaRequestWithHeaders("PUT", "/api/v1/provisioning/templates/"+name, nil, provisioningHeaders(disableProvenance), body, nil)
+}
+
+// alertRule is an alert rule of the provisioning API, with the fields that the Grafana API client doesn't support.
+type alertRule struct {
+	gapi.AlertRule
+	IsPaused             bool                           `json:"isPaused"`
+	NotificationSettings *alertRuleNotificationSettings `json:"notification_settings,omitempty"`
+	Record               *alertRuleRecord               `json:"record,omitempty"`
+}
+
+// alertRuleRecord makes a rule a recording rule, which writes the result of a query to a time series instead of alerting.
+type alertRuleRecord struct {
+	Metric              string `json:"metric"`
+	From                string `json:"from"`
+	TargetDatasourceUID string `json:"target_datasource_uid,omitempty"`
+}
+
+// alertRuleNotificationSettings routes the notifications of a rule to a contact point, instead of the notification policies.
+type alertRuleNotificationSettings struct {
+	Receiver          string   `json:"receiver"`
+	GroupBy           []string `json:"group_by,omitempty"`
+	GroupWait         string   `json:"group_wait,omitempty"`
+	GroupInterval     string   `json:"group_interval,omitempty"`
+	RepeatInterval    string   `json:"repeat_interval,omitempty"`
+	MuteTimeIntervals []string `json:"mute_time_intervals,omitempty"`
+}
+
+type alertRuleGroup struct {
+	Title     string      `json:"title"`
+	FolderUID string      `json:"folderUid"`
+	Interval  int64       `json:"interval"`
+	Rules     []alertRule `json:"rules"`
+}
+
+func (c *client) alertRule(uid string) (alertRule, error) {
+	var rule alertRule
+	err := c.grafanaRequest("GET", "/api/v1/provisioning/alert-rules/"+uid, nil, nil, &rule)
+	return rule, err
+}
+
+func (c *client) alertRules() ([]alertRule, error) {
+	var rules []alertRule
+	err := c.grafanaRequest("GET", "/api/v1/provisioning/alert-rules", nil, nil, &rules)
+	return rules, err
+}
+
+func (c *client) newAlertRule(rule *alertRule, disableProvenance bool) (string, error) {
+	var created alertRule
+	if err := c.grafanaRequestWithHeaders("POST", "/api/v1/provisioning/alert-rules", nil, provisioningHeaders(disableProvenance), rule, &created); err != nil {
+		return "", err
+	}
+	return created.UID, nil
+}
+
+func (c *client) updateAlertRule(rule *alertRule, disableProvenance bool) error {
+	return c.grafanaRequestWithHeaders("PUT", "/api/v1/provisioning/alert-rules/"+rule.UID, nil, provisioningHeaders(disableProvenance), rule, nil)
+}
+
+func (c *client) alertRuleGroup(folderUID, name string) (alertRuleGroup, error) {
+	var group alertRuleGroup
+	err := c.grafanaRequest("GET", fmt.Sprintf("/api/v1/provisioning/folder/%s/rule-groups/%s", folderUID, name), nil, nil, &group)
+	return group, err
+}
+
+func (c *client) setAlertRuleGroup(group *alertRuleGroup, disableProvenance bool) error {
+	requestPath := fmt.Sprintf("/api/v1/provisioning/folder/%s/rule-groups/%s", group.FolderUID, group.Title)
+	return c.grafanaRequestWithHeaders("PUT", requestPath, nil, provisioningHeaders(disableProvenance), group, nil)
+}
+
+// messageTemplate is a message template of the provisioning API. Unlike the Grafana API client, it includes the provenance.
+type messageTemplate struct {
+	Name       string `json:"name"`
+	Template   string `json:"template"`
+	Provenance string `json:"provenance"`
+}
+
+func (c *client) messageTemplate(name string) (messageTemplate, error) {
+	var tmpl messageTemplate
+	err := c.grafanaRequest("GET", "/api/v1/provisioning/templates/"+name, nil, nil, &tmpl)
+	return tmpl, err
+}
+
+// notificationPolicyTree returns the notification policy tree as raw JSON. Unlike the Grafana API client, this keeps the fields
+// of the routes that the client doesn't support, so that the tree can be saved again without losing them.
+func (c *client) notificationPolicyTree() (map[string]interface{}, error) {
+	tree := map[string]interface{}{}
+	err := c.grafanaRequest("GET", "/api/v1/provisioning/policies", nil, nil, &tree)
+	return tree, err
+}
+
+func (c *client) setNotificationPolicyTree(tree map[string]interface{}, disableProvenance bool) error {
+	body := make(map[string]interface{}, len(tree))
+	for k, v := range tree {
+		if k != "provenance" {
+			body[k] = v
+		}
+	}
+	return c.grafanaRequestWithHeaders("PUT", "/api/v1/provisioning/policies", nil, provisioningHeaders(disableProvenance), body, nil)
+}
+
+// muteTiming is a mute timing of the provisioning API, in the format of the Alertmanager configuration. Unlike the Grafana API client,
+// it includes the location of the time intervals.
+type muteTiming struct {
+	Name          string                   `json:"name" yaml:"name"`
+	TimeIntervals []muteTimingTimeInterval `json:"time_intervals" yaml:"time_intervals"`
+}
+
+type muteTimingTimeInterval struct {
+	Times       []muteTimingTimeRange `json:"times,omitempty" yaml:"times,omitempty"`
+	Weekdays    []string              `json:"weekdays,omitempty" yaml:"weekdays,omitempty"`
+	DaysOfMonth []string              `json:"days_of_month,omitempty" yaml:"days_of_month,omitempty"`
+	Months      []string              `json:"months,omitempty" yaml:"months,omitempty"`
+	Years       []string              `json:"years,omitempty" yaml:"years,omitempty"`
+	Location    string                `json:"location,omitempty" yaml:"location,omitempty"`
+}
+
+type muteTimingTimeRange struct {
+	StartTime string `json:"start_time" yaml:"start_time"`
+	EndTime   string `json:"end_time" yaml:"end_time"`
+}
+
+func (c *client) muteTimings() ([]muteTiming, error) {
+	var timings []muteTiming
+	err := c.grafanaRequest("GET", "/api/v1/provisioning/mute-timings", nil, nil, &timings)
+	return timings, err
+}
@@ -3,7 +3,11 @@ package grafana
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"path"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -35,3 +39,160 @@ func JSONBytesEqual(b1, b2 []byte) bool {
 
 	return reflect.DeepEqual(o1, o2)
 }
+
+// parseJSONPath splits a JSON pointer (RFC 6901) into its reference tokens.
+// Tokens may be glob patterns (see path.Match), `*` matches any object key or array index.
+func parseJSONPath(jsonPath string) ([]string, error) {
+	if !strings.HasPrefix(jsonPath, "/") || len(jsonPath) == 1 {
+		return nil, fmt.Errorf("%q is not a valid JSON pointer, it must start with `/` and reference a member of the document", jsonPath)
+	}
+	tokens := strings.Split(jsonPath[1:], "/")
+	for i, token := range tokens {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		if _, err := path.Match(token, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q in JSON pointer %q: %w", token, jsonPath, err)
+		}
+		tokens[i] = token
+	}
+	return tokens, nil
+}
+
+func validateJSONPath(i interface{}, k string) ([]string, []error) {
+	if _, err := parseJSONPath(i.(string)); err != nil {
+		return nil, []error{err}
+	}
+	return nil, nil
+}
+
+func jsonPathTokenMatches(token, key string) bool {
+	matched, err := path.Match(token, key)
+	return err == nil && matched
+}
+
+// removeJSONPaths removes the object members matching the given JSON pointers from a decoded JSON document.
+func removeJSONPaths(document interface{}, jsonPaths []string) {
+	for _, jsonPath := range jsonPaths {
+		if tokens, err := parseJSONPath(jsonPath); err == nil {
+			removeJSONPath(document, tokens)
+		}
+	}
+}
+
+func removeJSONPath(node interface{}, tokens []string) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for key, child := range n {
+			if !jsonPathTokenMatches(tokens[0], key) {
+				continue
+			}
+			if len(tokens) == 1 {
+				delete(n, key)
+			} else {
+				removeJSONPath(child, tokens[1:])
+			}
+		}
+	case []interface{}:
+		// Array items can't be removed in place, only their members can be
+		if len(tokens) == 1 {
+			return
+		}
+		for i, child := range n {
+			if jsonPathTokenMatches(tokens[0], strconv.Itoa(i)) {
+				removeJSONPath(child, tokens[1:])
+			}
+		}
+	}
+}
+
+// copyJSONPaths copies the object members matching the given JSON pointers from one decoded JSON document to another.
+// Members are only copied if their parent exists in the destination document.
+func copyJSONPaths(from, to interface{}, jsonPaths []string) {
+	for _, jsonPath := range jsonPaths {
+		if tokens, err := parseJSONPath(jsonPath); err == nil {
+			copyJSONPath(from, to, tokens)
+		}
+	}
+}
+
+func copyJSONPath(from, to interface{}, tokens []string) {
+	switch f := from.(type) {
+	case map[string]interface{}:
+		t, ok := to.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for key, child := range f {
+			if !jsonPathTokenMatches(tokens[0], key) {
+				continue
+			}
+			if len(tokens) == 1 {
+				t[key] = child
+			} else if toChild, ok := t[key]; ok {
+				copyJSONPath(child, toChild, tokens[1:])
+			}
+		}
+	case []interface{}:
+		t, ok := to.([]interface{})
+		if !ok || len(tokens) == 1 {
+			return
+		}
+		for i, child := range f {
+			if i < len(t) && jsonPathTokenMatches(tokens[0], strconv.Itoa(i)) {
+				copyJSONPath(child, t[i], tokens[1:])
+			}
+		}
+	}
+}
+
+// ignorePathsSchema is the schema of the `ignore_paths` attribute of resources that manage a JSON model.
+// It is used along with suppressJSONModelDiff and removeIgnoredJSONPaths.
+func ignorePathsSchema(modelDescription string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Description: fmt.Sprintf("JSON pointers to parts of the %s that are managed outside of Terraform, for example `/time`, `/refresh` or `/templating/list/*/current`. "+
+			"Tokens may be glob patterns. These paths are ignored when comparing the model with the one in Grafana, and their values in Grafana are kept on update.", modelDescription),
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validateJSONPath,
+		},
+	}
+}
+
+// removeIgnoredJSONPaths removes the paths listed in `ignore_paths` from a model. The data sources, which don't have
+// the attribute, keep the whole model.
+func removeIgnoredJSONPaths(d *schema.ResourceData, model map[string]interface{}) {
+	ignorePaths, _ := d.Get("ignore_paths").([]interface{})
+	removeJSONPaths(model, listToStringSlice(ignorePaths))
+}
+
+// suppressJSONModelDiff returns a DiffSuppressFunc comparing JSON models once prepared for comparison.
+// normalize is the StateFunc of the attribute, and prepare is applied to both models before normalizing them.
+// Read functions must apply prepare to the model read from Grafana as well.
+func suppressJSONModelDiff(normalize func(interface{}) string, prepare func(*schema.ResourceData, map[string]interface{})) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		if old == "" {
+			return false
+		}
+
+		// `new` went through the StateFunc, which may have hashed the model. The configured model is needed to prepare it.
+		configured := d.GetRawConfig().GetAttr(k)
+		if !configured.IsKnown() || configured.IsNull() {
+			return false
+		}
+		var newModel map[string]interface{}
+		if err := json.Unmarshal([]byte(configured.AsString()), &newModel); err != nil {
+			return false
+		}
+		prepare(d, newModel)
+
+		// The model in state is already prepared, unless it was read with different settings
+		var oldModel map[string]interface{}
+		if err := json.Unmarshal([]byte(old), &oldModel); err == nil {
+			prepare(d, oldModel)
+			old = normalize(oldModel)
+		}
+
+		return old == normalize(newModel)
+	}
+}
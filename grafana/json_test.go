@@ -0,0 +1,93 @@
+package grafana
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	IsUnitTest(t)
+
+	for _, tc := range []struct {
+		path     string
+		expected []string
+		err      bool
+	}{
+		{path: "/time", expected: []string{"time"}},
+		{path: "/templating/list/*/current", expected: []string{"templating", "list", "*", "current"}},
+		{path: "/a~1b/c~0d", expected: []string{"a/b", "c~d"}},
+		{path: "time", err: true},
+		{path: "/", err: true},
+		{path: "/panels/[", err: true},
+	} {
+		tokens, err := parseJSONPath(tc.path)
+		if tc.err {
+			if err == nil {
+				t.Errorf("expected an error for %q", tc.path)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %q: %s", tc.path, err)
+		}
+		if !reflect.DeepEqual(tokens, tc.expected) {
+			t.Errorf("expected %v for %q, got %v", tc.expected, tc.path, tokens)
+		}
+	}
+}
+
+func TestRemoveJSONPaths(t *testing.T) {
+	IsUnitTest(t)
+
+	document := testJSONDocument(t, `{
+		"title": "test",
+		"time": {"from": "now-6h"},
+		"templating": {"list": [{"name": "a", "current": {"value": "1"}}, {"name": "b"}]},
+		"panels": [{"title": "p", "options": {}}]
+	}`)
+	removeJSONPaths(document, []string{"/time", "/templating/list/*/current", "/panels/0", "/missing/path"})
+
+	expected := testJSONDocument(t, `{
+		"title": "test",
+		"templating": {"list": [{"name": "a"}, {"name": "b"}]},
+		"panels": [{"title": "p", "options": {}}]
+	}`)
+	if !reflect.DeepEqual(document, expected) {
+		t.Errorf("expected %v, got %v", expected, document)
+	}
+}
+
+func TestCopyJSONPaths(t *testing.T) {
+	IsUnitTest(t)
+
+	from := testJSONDocument(t, `{
+		"title": "remote",
+		"refresh": "5m",
+		"templating": {"list": [{"name": "a", "current": {"value": "2"}}, {"name": "b", "current": {"value": "3"}}]}
+	}`)
+	to := testJSONDocument(t, `{
+		"title": "local",
+		"templating": {"list": [{"name": "a", "current": {"value": "1"}}]}
+	}`)
+	copyJSONPaths(from, to, []string{"/refresh", "/templating/list/*/current", "/time"})
+
+	expected := testJSONDocument(t, `{
+		"title": "local",
+		"refresh": "5m",
+		"templating": {"list": [{"name": "a", "current": {"value": "2"}}]}
+	}`)
+	if !reflect.DeepEqual(to, expected) {
+		t.Errorf("expected %v, got %v", expected, to)
+	}
+}
+
+func testJSONDocument(t *testing.T, document string) map[string]interface{} {
+	t.Helper()
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(document), &result); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
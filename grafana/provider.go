@@ -48,33 +48,44 @@ func Provider(version string) func() *schema.Provider {
 		// Resources that require the Grafana client to exist.
 		grafanaClientResources = addResourcesMetadataValidation(grafanaClientPresent, map[string]*schema.Resource{
 			// Grafana
-			"grafana_annotation":               ResourceAnnotation(),
-			"grafana_alert_notification":       ResourceAlertNotification(),
-			"grafana_builtin_role_assignment":  ResourceBuiltInRoleAssignment(),
-			"grafana_contact_point":            ResourceContactPoint(),
-			"grafana_dashboard":                ResourceDashboard(),
-			"grafana_dashboard_permission":     ResourceDashboardPermission(),
-			"grafana_data_source":              ResourceDataSource(),
-			"grafana_data_source_permission":   ResourceDatasourcePermission(),
-			"grafana_folder":                   ResourceFolder(),
-			"grafana_folder_permission":        ResourceFolderPermission(),
-			"grafana_library_panel":            ResourceLibraryPanel(),
-			"grafana_message_template":         ResourceMessageTemplate(),
-			"grafana_mute_timing":              ResourceMuteTiming(),
-			"grafana_notification_policy":      ResourceNotificationPolicy(),
-			"grafana_organization":             ResourceOrganization(),
-			"grafana_organization_preferences": ResourceOrganizationPreferences(),
-			"grafana_playlist":                 ResourcePlaylist(),
-			"grafana_report":                   ResourceReport(),
-			"grafana_role":                     ResourceRole(),
-			"grafana_role_assignment":          ResourceRoleAssignment(),
-			"grafana_rule_group":               ResourceRuleGroup(),
-			"grafana_team":                     ResourceTeam(),
-			"grafana_team_preferences":         ResourceTeamPreferences(),
-			"grafana_team_external_group":      ResourceTeamExternalGroup(),
-			"grafana_service_account_token":    ResourceServiceAccountToken(),
-			"grafana_service_account":          ResourceServiceAccount(),
-			"grafana_user":                     ResourceUser(),
+			"grafana_annotation":                  ResourceAnnotation(),
+			"grafana_alert_notification":          ResourceAlertNotification(),
+			"grafana_alert_rule":                  ResourceAlertRule(),
+			"grafana_builtin_role_assignment":     ResourceBuiltInRoleAssignment(),
+			"grafana_contact_point":               ResourceContactPoint(),
+			"grafana_dashboard":                   ResourceDashboard(),
+			"grafana_dashboard_permission":        ResourceDashboardPermission(),
+			"grafana_dashboard_gnet":              ResourceDashboardGnet(),
+			"grafana_dashboard_public":            ResourceDashboardPublic(),
+			"grafana_dashboard_permission_item":   ResourceDashboardPermissionItem(),
+			"grafana_dashboard_restore":           ResourceDashboardRestore(),
+			"grafana_dashboard_set":               ResourceDashboardSet(),
+			"grafana_dashboard_snapshot":          ResourceDashboardSnapshot(),
+			"grafana_data_source":                 ResourceDataSource(),
+			"grafana_data_source_permission":      ResourceDatasourcePermission(),
+			"grafana_data_source_permission_item": ResourceDatasourcePermissionItem(),
+			"grafana_folder":                      ResourceFolder(),
+			"grafana_folder_permission":           ResourceFolderPermission(),
+			"grafana_folder_permission_item":      ResourceFolderPermissionItem(),
+			"grafana_library_panel":               ResourceLibraryPanel(),
+			"grafana_message_template":            ResourceMessageTemplate(),
+			"grafana_mute_timing":                 ResourceMuteTiming(),
+			"grafana_notification_policy":         ResourceNotificationPolicy(),
+			"grafana_notification_policy_route":   ResourceNotificationPolicyRoute(),
+			"grafana_organization":                ResourceOrganization(),
+			"grafana_organization_preferences":    ResourceOrganizationPreferences(),
+			"grafana_playlist":                    ResourcePlaylist(),
+			"grafana_report":                      ResourceReport(),
+			"grafana_role":                        ResourceRole(),
+			"grafana_role_assignment":             ResourceRoleAssignment(),
+			"grafana_rule_group":                  ResourceRuleGroup(),
+			"grafana_rule_group_interval":         ResourceRuleGroupInterval(),
+			"grafana_team":                        ResourceTeam(),
+			"grafana_team_preferences":            ResourceTeamPreferences(),
+			"grafana_team_external_group":         ResourceTeamExternalGroup(),
+			"grafana_service_account_token":       ResourceServiceAccountToken(),
+			"grafana_service_account":             ResourceServiceAccount(),
+			"grafana_user":                        ResourceUser(),
 
 			// Machine Learning
 			"grafana_machine_learning_job": ResourceMachineLearningJob(),
@@ -107,6 +118,7 @@ func Provider(version string) func() *schema.Provider {
 		// Datasources that require the Grafana client to exist.
 		grafanaClientDatasources = addResourcesMetadataValidation(grafanaClientPresent, map[string]*schema.Resource{
 			"grafana_dashboard":                DatasourceDashboard(),
+			"grafana_dashboard_versions":       DatasourceDashboardVersions(),
 			"grafana_dashboards":               DatasourceDashboards(),
 			"grafana_folder":                   DatasourceFolder(),
 			"grafana_folders":                  DatasourceFolders(),
@@ -115,6 +127,9 @@ func Provider(version string) func() *schema.Provider {
 			"grafana_team":                     DatasourceTeam(),
 			"grafana_organization":             DatasourceOrganization(),
 			"grafana_organization_preferences": DatasourceOrganizationPreferences(),
+			"grafana_alert_rules":              DatasourceAlertRules(),
+			"grafana_alert_rule_group":         DatasourceRuleGroup(),
+			"grafana_notification_policy":      DatasourceNotificationPolicy(),
 		})
 
 		// Datasources that require the Synthetic Monitoring client to exist.
@@ -216,7 +231,7 @@ func Provider(version string) func() *schema.Provider {
 					Type:         schema.TypeString,
 					Optional:     true,
 					DefaultFunc:  schema.EnvDefaultFunc("GRAFANA_CLOUD_API_URL", "https://grafana.com"),
-					Description:  "Grafana Cloud's API URL. May alternatively be set via the `GRAFANA_CLOUD_API_URL` environment variable.",
+					Description:  "Grafana Cloud's API URL. Community dashboards are also downloaded from this URL by `grafana_dashboard_gnet`. May alternatively be set via the `GRAFANA_CLOUD_API_URL` environment variable.",
 					ValidateFunc: validation.IsURLWithHTTPorHTTPS,
 				},
 
@@ -271,6 +286,11 @@ func Provider(version string) func() *schema.Provider {
 			),
 
 			DataSourcesMap: mergeResourceMaps(
+				map[string]*schema.Resource{
+					// These ones only build their output from their attributes, they don't need any client
+					"grafana_dashboard_json":             DatasourceDashboardJSON(),
+					"grafana_rule_group_from_prometheus": DatasourceRuleGroupFromPrometheus(),
+				},
 				grafanaClientDatasources,
 				smClientDatasources,
 				onCallClientDatasources,
@@ -290,6 +310,9 @@ type client struct {
 	gapiConfig *gapi.Config
 	gcloudapi  *gapi.Client
 
+	// cloudAPIURL is the URL of the Grafana.com API, which also serves the community dashboards
+	cloudAPIURL string
+
 	smapi *smapi.Client
 	smURL string
 
@@ -297,7 +320,57 @@ type client struct {
 
 	onCallAPI *onCallAPI.Client
 
+	// resourcePermissionsSupport caches whether the access control API supports each resource type
+	resourcePermissionsSupport      map[string]bool
+	resourcePermissionsSupportMutex sync.Mutex
+
 	alertingMutex sync.Mutex
+
+	// plannedNames holds the names of the resources planned in the current run, by kind, such as the contact points or the
+	// data source UIDs. The resources that reference them are planned after them, so they can check their references against it.
+	plannedNames      map[string]map[string]bool
+	plannedNamesMutex sync.Mutex
+}
+
+// The kinds of names registered in the planned names of the client.
+const (
+	plannedContactPoints  = "contact_point"
+	plannedMuteTimings    = "mute_timing"
+	plannedDatasourceUIDs = "data_source_uid"
+)
+
+// registerPlannedName returns a CustomizeDiff function that records the value of the given attribute in the planned names
+// of the client, so that the resources that reference it can be planned before it exists in Grafana.
+func registerPlannedName(kind, attr string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		client, ok := meta.(*client)
+		name, _ := d.Get(attr).(string)
+		if !ok || !d.NewValueKnown(attr) || name == "" {
+			return nil
+		}
+
+		client.plannedNamesMutex.Lock()
+		defer client.plannedNamesMutex.Unlock()
+		if client.plannedNames == nil {
+			client.plannedNames = map[string]map[string]bool{}
+		}
+		if client.plannedNames[kind] == nil {
+			client.plannedNames[kind] = map[string]bool{}
+		}
+		client.plannedNames[kind][name] = true
+		return nil
+	}
+}
+
+// plannedNamesOf returns the names of the given kind planned in the current run.
+func (c *client) plannedNamesOf(kind string) map[string]bool {
+	c.plannedNamesMutex.Lock()
+	defer c.plannedNamesMutex.Unlock()
+	names := make(map[string]bool, len(c.plannedNames[kind]))
+	for name := range c.plannedNames[kind] {
+		names[name] = true
+	}
+	return names
 }
 
 func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -326,6 +399,7 @@ func configure(version string, p *schema.Provider) func(context.Context, *schema
 				return nil, diag.FromErr(err)
 			}
 		}
+		c.cloudAPIURL = d.Get("cloud_api_url").(string)
 		c.smURL = d.Get("sm_url").(string)
 		if smToken := d.Get("sm_access_token").(string); smToken != "" {
 			c.smapi = smapi.NewClient(c.smURL, smToken, nil)
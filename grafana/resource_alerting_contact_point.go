@@ -8,6 +8,7 @@ import (
 
 	gapi "github.com/grafana/grafana-api-golang-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -45,6 +46,7 @@ This resource requires Grafana 9.1.0 or later.
 		ReadContext:   readContactPoint,
 		UpdateContext: updateContactPoint,
 		DeleteContext: deleteContactPoint,
+		CustomizeDiff: customdiff.All(registerPlannedName(plannedContactPoints, "name"), forceNewOnDisableProvenance),
 
 		Importer: &schema.ResourceImporter{
 			StateContext: importContactPoint,
@@ -57,6 +59,7 @@ This resource requires Grafana 9.1.0 or later.
 				Required:    true,
 				Description: "The name of the contact point.",
 			},
+			"disable_provenance": disableProvenanceSchema(),
 		},
 	}
 
@@ -127,7 +130,8 @@ func readContactPoint(ctx context.Context, data *schema.ResourceData, meta inter
 
 func createContactPoint(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lock := &meta.(*client).alertingMutex
-	client := meta.(*client).gapi
+	client := meta.(*client)
+	disableProvenance := data.Get("disable_provenance").(bool)
 
 	ps := unpackContactPoints(data)
 	uids := make([]string, 0, len(ps))
@@ -135,7 +139,7 @@ func createContactPoint(ctx context.Context, data *schema.ResourceData, meta int
 	lock.Lock()
 	defer lock.Unlock()
 	for i := range ps {
-		uid, err := client.NewContactPoint(&ps[i])
+		uid, err := client.newContactPoint(&ps[i], disableProvenance)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -148,7 +152,8 @@ func createContactPoint(ctx context.Context, data *schema.ResourceData, meta int
 
 func updateContactPoint(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lock := &meta.(*client).alertingMutex
-	client := meta.(*client).gapi
+	client := meta.(*client)
+	disableProvenance := data.Get("disable_provenance").(bool)
 
 	existingUIDs := unpackUIDs(data.Id())
 	ps := unpackContactPoints(data)
@@ -159,10 +164,10 @@ func updateContactPoint(ctx context.Context, data *schema.ResourceData, meta int
 	defer lock.Unlock()
 	for i := range ps {
 		delete(unprocessedUIDs, ps[i].UID)
-		err := client.UpdateContactPoint(&ps[i])
+		err := client.updateContactPoint(&ps[i], disableProvenance)
 		if err != nil {
 			if strings.HasPrefix(err.Error(), "status: 404") {
-				uid, err := client.NewContactPoint(&ps[i])
+				uid, err := client.newContactPoint(&ps[i], disableProvenance)
 				newUIDs = append(newUIDs, uid)
 				if err != nil {
 					return diag.FromErr(err)
@@ -177,7 +182,7 @@ func updateContactPoint(ctx context.Context, data *schema.ResourceData, meta int
 	// Any UIDs still left in the state that we haven't seen must map to deleted receivers.
 	// Delete them on the server and drop them from state.
 	for u := range unprocessedUIDs {
-		if err := client.DeleteContactPoint(u); err != nil {
+		if err := client.gapi.DeleteContactPoint(u); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -233,6 +238,7 @@ func packContactPoints(ps []gapi.ContactPoint, data *schema.ResourceData) error
 	pointsPerNotifier := map[notifier][]interface{}{}
 	for _, p := range ps {
 		data.Set("name", p.Name)
+		data.Set("disable_provenance", p.Provenance == "")
 
 		for _, n := range notifiers {
 			if p.Type == n.meta().typeStr {
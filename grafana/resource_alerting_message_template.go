@@ -23,6 +23,7 @@ This resource requires Grafana 9.1.0 or later.
 		ReadContext:   readMessageTemplate,
 		UpdateContext: updateMessageTemplate,
 		DeleteContext: deleteMessageTemplate,
+		CustomizeDiff: forceNewOnDisableProvenance,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -35,6 +36,7 @@ This resource requires Grafana 9.1.0 or later.
 				ForceNew:    true,
 				Description: "The name of the message template.",
 			},
+			"disable_provenance": disableProvenanceSchema(),
 			"template": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -48,10 +50,10 @@ This resource requires Grafana 9.1.0 or later.
 }
 
 func readMessageTemplate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gapi
+	client := meta.(*client)
 
 	name := data.Id()
-	tmpl, err := client.MessageTemplate(name)
+	tmpl, err := client.messageTemplate(name)
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "status: 404") {
 			log.Printf("[WARN] removing template %s from state because it no longer exists in grafana", name)
@@ -64,19 +66,20 @@ func readMessageTemplate(ctx context.Context, data *schema.ResourceData, meta in
 	data.SetId(tmpl.Name)
 	data.Set("name", tmpl.Name)
 	data.Set("template", tmpl.Template)
+	data.Set("disable_provenance", tmpl.Provenance == "")
 
 	return nil
 }
 
 func createMessageTemplate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lock := &meta.(*client).alertingMutex
-	client := meta.(*client).gapi
+	client := meta.(*client)
 	name := data.Get("name").(string)
 	content := data.Get("template").(string)
 
 	lock.Lock()
 	defer lock.Unlock()
-	if err := client.SetMessageTemplate(name, content); err != nil {
+	if err := client.setMessageTemplate(name, content, data.Get("disable_provenance").(bool)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -86,13 +89,13 @@ func createMessageTemplate(ctx context.Context, data *schema.ResourceData, meta
 
 func updateMessageTemplate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lock := &meta.(*client).alertingMutex
-	client := meta.(*client).gapi
+	client := meta.(*client)
 	name := data.Get("name").(string)
 	content := data.Get("template").(string)
 
 	lock.Lock()
 	defer lock.Unlock()
-	if err := client.SetMessageTemplate(name, content); err != nil {
+	if err := client.setMessageTemplate(name, content, data.Get("disable_provenance").(bool)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -1,6 +1,7 @@
 package grafana
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -110,3 +111,41 @@ func testMessageTemplateCheckDestroy(mt *gapi.AlertingMessageTemplate) resource.
 		return nil
 	}
 }
+
+func TestMessageTemplateDisableProvenanceDiff(t *testing.T) {
+	IsUnitTest(t)
+
+	for _, tc := range []struct {
+		name          string
+		state         string
+		config        bool
+		expectReplace bool
+	}{
+		// Templates created in the Grafana UI are read without provenance, Grafana adds it on update
+		{name: "provenance added", state: "true", config: false, expectReplace: false},
+		{name: "provenance removed", state: "false", config: true, expectReplace: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID:         "my-template",
+				Attributes: map[string]string{"id": "my-template", "name": "my-template", "template": "{{ define \"my-template\" }}{{ end }}", "disable_provenance": tc.state},
+			}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"name":               "my-template",
+				"template":           "{{ define \"my-template\" }}{{ end }}",
+				"disable_provenance": tc.config,
+			})
+
+			diff, err := ResourceMessageTemplate().SimpleDiff(context.Background(), state, config, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff == nil || diff.Attributes["disable_provenance"] == nil {
+				t.Fatalf("expected a diff of disable_provenance, got %v", diff)
+			}
+			if replace := diff.RequiresNew(); replace != tc.expectReplace {
+				t.Errorf("expected replacement: %t, got %t", tc.expectReplace, replace)
+			}
+		})
+	}
+}
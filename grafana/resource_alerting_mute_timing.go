@@ -8,6 +8,7 @@ import (
 
 	gapi "github.com/grafana/grafana-api-golang-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -26,6 +27,7 @@ This resource requires Grafana 9.1.0 or later.
 		ReadContext:   readMuteTiming,
 		UpdateContext: updateMuteTiming,
 		DeleteContext: deleteMuteTiming,
+		CustomizeDiff: customdiff.All(registerPlannedName(plannedMuteTimings, "name"), forceNewOnDisableProvenance),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -38,6 +40,7 @@ This resource requires Grafana 9.1.0 or later.
 				ForceNew:    true,
 				Description: "The name of the mute timing.",
 			},
+			"disable_provenance": disableProvenanceSchema(),
 
 			"intervals": {
 				// List instead of set is necessary here. We rely on diff-suppression on the `months` field.
@@ -127,18 +130,19 @@ func readMuteTiming(ctx context.Context, data *schema.ResourceData, meta interfa
 	data.SetId(mt.Name)
 	data.Set("name", mt.Name)
 	data.Set("intervals", packIntervals(mt.TimeIntervals))
+	data.Set("disable_provenance", mt.Provenance == "")
 	return nil
 }
 
 func createMuteTiming(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lock := &meta.(*client).alertingMutex
-	client := meta.(*client).gapi
+	client := meta.(*client)
 
 	mt := unpackMuteTiming(data)
 
 	lock.Lock()
 	defer lock.Unlock()
-	if err := client.NewMuteTiming(&mt); err != nil {
+	if err := client.newMuteTiming(&mt, data.Get("disable_provenance").(bool)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -148,13 +152,13 @@ func createMuteTiming(ctx context.Context, data *schema.ResourceData, meta inter
 
 func updateMuteTiming(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	lock := &meta.(*client).alertingMutex
-	client := meta.(*client).gapi
+	client := meta.(*client)
 
 	mt := unpackMuteTiming(data)
 
 	lock.Lock()
 	defer lock.Unlock()
-	if err := client.UpdateMuteTiming(&mt); err != nil {
+	if err := client.updateMuteTiming(&mt, data.Get("disable_provenance").(bool)); err != nil {
 		return diag.FromErr(err)
 	}
 	return readMuteTiming(ctx, data, meta)
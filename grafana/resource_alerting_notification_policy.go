@@ -2,10 +2,13 @@ package grafana
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -15,6 +18,9 @@ func ResourceNotificationPolicy() *schema.Resource {
 		Description: `
 Sets the global notification policy for Grafana. Note that this resource manages the entire notification policy tree, and will overwrite any existing policies.
 
+The policies are checked when planning: their matchers and durations must be valid. With ` + "`strict_validation`" + `, the contact points and mute timings
+they reference must also exist in Grafana or be planned in the same run.
+
 * [Official documentation](https://grafana.com/docs/grafana/latest/alerting/notifications/)
 * [HTTP API](https://grafana.com/docs/grafana/next/developers/http_api/alerting_provisioning/#notification-policies)
 
@@ -25,6 +31,7 @@ This resource requires Grafana 9.1.0 or later.
 		ReadContext:   readNotificationPolicy,
 		UpdateContext: updateNotificationPolicy,
 		DeleteContext: deleteNotificationPolicy,
+		CustomizeDiff: customdiff.All(customizeNotificationPolicyDiff, forceNewOnDisableProvenance),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -32,40 +39,80 @@ This resource requires Grafana 9.1.0 or later.
 		SchemaVersion: 0,
 		Schema: map[string]*schema.Schema{
 			"contact_point": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The default contact point to route all unmatched notifications to.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"contact_point", "policy_tree_json", "policy_tree_yaml"},
+				// RequiredWith is only checked when the attribute is set, so the pair is enforced on both sides
+				RequiredWith: []string{"group_by"},
+				Description: "The default contact point to route all unmatched notifications to. Required unless the tree is set with `policy_tree_json` or `policy_tree_yaml`. " +
+					"If the contact point is created in the same apply, reference the `name` attribute of its `grafana_contact_point` resource or add it to `depends_on`, so that it's created first.",
 			},
+			"disable_provenance": disableProvenanceSchema(),
 			"group_by": {
-				Type:        schema.TypeList,
-				Required:    true,
-				Description: "A list of alert labels to group alerts into notifications by. Use the special label `...` to group alerts by all labels, effectively disabling grouping.",
+				Type:          schema.TypeList,
+				Optional:      true,
+				RequiredWith:  []string{"contact_point"},
+				ConflictsWith: policyTreeAttributes,
+				Description:   "A list of alert labels to group alerts into notifications by. Use the special label `...` to group alerts by all labels, effectively disabling grouping. Required with `contact_point`.",
 				Elem: &schema.Schema{
 					Type:         schema.TypeString,
 					ValidateFunc: validation.StringIsNotEmpty,
 				},
 			},
 			"group_wait": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Time to wait to buffer alerts of the same group before sending a notification. Default is 30 seconds.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: policyTreeAttributes,
+				Description:   "Time to wait to buffer alerts of the same group before sending a notification. Default is 30 seconds.",
 			},
 			"group_interval": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Minimum time interval between two notifications for the same group. Default is 5 minutes.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: policyTreeAttributes,
+				Description:   "Minimum time interval between two notifications for the same group. Default is 5 minutes.",
 			},
 			"repeat_interval": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Minimum time interval for re-sending a notification if an alert is still firing. Default is 4 hours.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: policyTreeAttributes,
+				Description:   "Minimum time interval for re-sending a notification if an alert is still firing. Default is 4 hours.",
 			},
 
 			"policy": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				Description: "Routing rules for specific label sets.",
-				Elem:        policySchema(supportedPolicyTreeDepth),
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: policyTreeAttributes,
+				Description:   fmt.Sprintf("Routing rules for specific label sets. They can be nested up to a depth of %d, use `policy_tree_json` or `policy_tree_yaml` for deeper trees.", supportedPolicyTreeDepth),
+				Elem:          policySchema(supportedPolicyTreeDepth),
+			},
+
+			"policy_tree_json": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ExactlyOneOf:     []string{"contact_point", "policy_tree_json", "policy_tree_yaml"},
+				ValidateFunc:     validatePolicyTree(policyTreeFormatJSON),
+				DiffSuppressFunc: suppressPolicyTreeDiff(policyTreeFormatJSON),
+				Description: "The entire notification policy tree as JSON, with no depth limit, instead of the other attributes. " +
+					"It can be in the format of the Grafana API (with `object_matchers`) or of the Alertmanager configuration (with `matchers`, `match` or `match_re`). " +
+					"The matchers and durations are normalized, so equivalent trees don't produce a diff. " +
+					"The contact points and mute timings created in the same apply must be in `depends_on`, so that they're created first.",
+			},
+			"policy_tree_yaml": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ExactlyOneOf:     []string{"contact_point", "policy_tree_json", "policy_tree_yaml"},
+				ValidateFunc:     validatePolicyTree(policyTreeFormatYAML),
+				DiffSuppressFunc: suppressPolicyTreeDiff(policyTreeFormatYAML),
+				Description: "The entire notification policy tree as YAML, like `policy_tree_json`. " +
+					"The `route` section of an Alertmanager configuration can be used directly, with or without its `route` key.",
+			},
+			"strict_validation": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Set to true to fail the plan when a contact point or mute timing referenced by the policies neither exists in Grafana nor is planned in the same run. " +
+					"Terraform only plans the resources that create them before the notification policy if they are referenced through their `name` attribute or listed in `depends_on`, " +
+					"so names written as literals may not be planned yet. Without it, the missing contact points and mute timings are reported if Grafana rejects the tree.",
 			},
 		},
 	}
@@ -77,6 +124,9 @@ const supportedPolicyTreeDepth = 4
 
 const PolicySingletonID = "policy"
 
+// policyTreeAttributes are the attributes that set the entire notification policy tree as a document.
+var policyTreeAttributes = []string{"policy_tree_json", "policy_tree_yaml"}
+
 // policySchema recursively builds a resource schema for the policy resource. Each policy contains a list of policies.
 // Since Terraform does not support infinitely recursive schemas, we instead define the resource to a finite depth.
 func policySchema(depth uint) *schema.Resource {
@@ -87,9 +137,10 @@ func policySchema(depth uint) *schema.Resource {
 	resource := &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"contact_point": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The contact point to route notifications that match this rule to.",
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The contact point to route notifications that match this rule to. " +
+					"If it's created in the same apply, reference the `name` attribute of its `grafana_contact_point` resource or add it to `depends_on`.",
 			},
 			"group_by": {
 				Type:        schema.TypeList,
@@ -124,9 +175,10 @@ func policySchema(depth uint) *schema.Resource {
 				},
 			},
 			"mute_timings": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				Description: "A list of mute timing names to apply to alerts that match this policy.",
+				Type:     schema.TypeList,
+				Optional: true,
+				Description: "A list of mute timing names to apply to alerts that match this policy. " +
+					"If one is created in the same apply, reference the `name` attribute of its `grafana_mute_timing` resource or add it to `depends_on`.",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -167,6 +219,9 @@ func policySchema(depth uint) *schema.Resource {
 }
 
 func readNotificationPolicy(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if attr, format := policyTreeAttribute(data); attr != "" {
+		return readNotificationPolicyTree(data, meta, attr, format)
+	}
 	client := meta.(*client).gapi
 
 	npt, err := client.NotificationPolicyTree()
@@ -175,23 +230,32 @@ func readNotificationPolicy(ctx context.Context, data *schema.ResourceData, meta
 	}
 
 	packNotifPolicy(npt, data)
+	data.Set("disable_provenance", npt.Provenance == "")
 	data.SetId(PolicySingletonID)
 	return nil
 }
 
 func createNotificationPolicy(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if attr, format := policyTreeAttribute(data); attr != "" {
+		if err := setNotificationPolicyTree(data, meta, attr, format); err != nil {
+			return diag.FromErr(err)
+		}
+		data.SetId(PolicySingletonID)
+		return readNotificationPolicy(ctx, data, meta)
+	}
+
 	lock := &meta.(*client).alertingMutex
-	client := meta.(*client).gapi
+	client := meta.(*client)
 
-	npt, err := unpackNotifPolicy(data)
+	tree, err := unpackNotifPolicyTree(data)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	lock.Lock()
 	defer lock.Unlock()
-	if err := client.SetNotificationPolicyTree(&npt); err != nil {
-		return diag.FromErr(err)
+	if err := client.setNotificationPolicyTree(tree, data.Get("disable_provenance").(bool)); err != nil {
+		return diag.FromErr(notificationPolicyReferenceError(client, tree, err))
 	}
 
 	data.SetId(PolicySingletonID)
@@ -199,18 +263,26 @@ func createNotificationPolicy(ctx context.Context, data *schema.ResourceData, me
 }
 
 func updateNotificationPolicy(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if attr, format := policyTreeAttribute(data); attr != "" {
+		if err := setNotificationPolicyTree(data, meta, attr, format); err != nil {
+			return diag.FromErr(err)
+		}
+		data.SetId(PolicySingletonID)
+		return readNotificationPolicy(ctx, data, meta)
+	}
+
 	lock := &meta.(*client).alertingMutex
-	client := meta.(*client).gapi
+	client := meta.(*client)
 
-	npt, err := unpackNotifPolicy(data)
+	tree, err := unpackNotifPolicyTree(data)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	lock.Lock()
 	defer lock.Unlock()
-	if err := client.SetNotificationPolicyTree(&npt); err != nil {
-		return diag.FromErr(err)
+	if err := client.setNotificationPolicyTree(tree, data.Get("disable_provenance").(bool)); err != nil {
+		return diag.FromErr(notificationPolicyReferenceError(client, tree, err))
 	}
 
 	return readNotificationPolicy(ctx, data, meta)
@@ -229,6 +301,72 @@ func deleteNotificationPolicy(ctx context.Context, data *schema.ResourceData, me
 	return diag.Diagnostics{}
 }
 
+// policyTreeAttribute returns the attribute that sets the tree as a document, and its format, if any.
+func policyTreeAttribute(data *schema.ResourceData) (string, string) {
+	if data.Get("policy_tree_json").(string) != "" {
+		return "policy_tree_json", policyTreeFormatJSON
+	}
+	if data.Get("policy_tree_yaml").(string) != "" {
+		return "policy_tree_yaml", policyTreeFormatYAML
+	}
+	return "", ""
+}
+
+func readNotificationPolicyTree(data *schema.ResourceData, meta interface{}, attr, format string) diag.Diagnostics {
+	raw, err := meta.(*client).notificationPolicyTree()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	provenance, _ := raw["provenance"].(string)
+	tree, err := normalizePolicyRoute(raw)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The configured document is kept in the state while it's equivalent to the tree
+	if current, err := parsePolicyTree(data.Get(attr).(string), format); err != nil || !reflect.DeepEqual(current, tree) {
+		document, err := formatPolicyTree(tree, format)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		data.Set(attr, document)
+	}
+	data.Set("disable_provenance", provenance == "")
+	data.SetId(PolicySingletonID)
+	return nil
+}
+
+// setNotificationPolicyTree saves the tree set as a document.
+func setNotificationPolicyTree(data *schema.ResourceData, meta interface{}, attr, format string) error {
+	lock := &meta.(*client).alertingMutex
+	tree, err := parsePolicyTree(data.Get(attr).(string), format)
+	if err != nil {
+		return err
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if err := meta.(*client).setNotificationPolicyTree(tree, data.Get("disable_provenance").(bool)); err != nil {
+		return notificationPolicyReferenceError(meta.(*client), tree, err)
+	}
+	return nil
+}
+
+func validatePolicyTree(format string) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		if _, err := parsePolicyTree(i.(string), format); err != nil {
+			return nil, []error{fmt.Errorf("%s: %s", k, err)}
+		}
+		return nil, nil
+	}
+}
+
+func suppressPolicyTreeDiff(format string) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		return policyTreesEqual(old, new, format)
+	}
+}
+
 func packNotifPolicy(npt gapi.NotificationPolicyTree, data *schema.ResourceData) {
 	data.Set("contact_point", npt.Receiver)
 	data.Set("group_by", npt.GroupBy)
@@ -318,6 +456,21 @@ func unpackNotifPolicy(data *schema.ResourceData) (gapi.NotificationPolicyTree,
 	}, nil
 }
 
+// unpackNotifPolicyTree returns the tree of the policy blocks in the format of the Grafana API.
+func unpackNotifPolicyTree(data *schema.ResourceData) (map[string]interface{}, error) {
+	npt, err := unpackNotifPolicy(data)
+	if err != nil {
+		return nil, err
+	}
+	treeJSON, err := json.Marshal(npt)
+	if err != nil {
+		return nil, err
+	}
+	tree := map[string]interface{}{}
+	err = json.Unmarshal(treeJSON, &tree)
+	return tree, err
+}
+
 func unpackSpecificPolicy(p interface{}) (gapi.SpecificPolicy, error) {
 	json := p.(map[string]interface{})
 	policy := gapi.SpecificPolicy{
@@ -0,0 +1,265 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var policyRouteMatcherRegexp = regexp.MustCompile(`^\s*([^\s=!~,"{}]+)\s*(=~|!~|!=|=)\s*("(?:[^"\\]|\\.)*")\s*(?:,|$)`)
+
+func ResourceNotificationPolicyRoute() *schema.Resource {
+	routeSchema := policySchema(supportedPolicyTreeDepth).Schema
+	routeSchema["matcher"].Optional = false
+	routeSchema["matcher"].Required = true
+	routeSchema["matcher"].MinItems = 1
+	routeSchema["matcher"].Description = "Describes which labels this route matches. An alert must match ALL matchers to be accepted by this route. " +
+		"The matchers identify the route among the child routes of the root policy, so two routes can't have the same matchers."
+
+	return &schema.Resource{
+		Description: `
+Manages one child route of the root notification policy, identified by its matchers. The other routes of the notification policy tree are left untouched,
+so that each team can manage its own routes. New routes are added after the existing child routes of the root policy.
+
+The ` + "`grafana_notification_policy`" + ` resource manages the entire tree. To use both, add ` + "`policy`" + ` to the ` + "`ignore_changes`" + ` of its ` + "`lifecycle`" + `,
+so that it doesn't remove the routes managed by this resource.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/alerting/notifications/)
+* [HTTP API](https://grafana.com/docs/grafana/next/developers/http_api/alerting_provisioning/#notification-policies)
+
+This resource requires Grafana 9.1.0 or later.
+`,
+
+		CreateContext: createNotificationPolicyRoute,
+		ReadContext:   readNotificationPolicyRoute,
+		UpdateContext: updateNotificationPolicyRoute,
+		DeleteContext: deleteNotificationPolicyRoute,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		SchemaVersion: 0,
+		Schema:        routeSchema,
+	}
+}
+
+func readNotificationPolicyRoute(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tree, err := meta.(*client).notificationPolicyTree()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	routes, _ := tree["routes"].([]interface{})
+	index, err := findPolicyRoute(routes, data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if index < 0 {
+		log.Printf("[WARN] removing notification policy route %s from state because it no longer exists in grafana", data.Id())
+		data.SetId("")
+		return nil
+	}
+
+	route, err := policyRouteFromJSON(routes[index])
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	packed := packSpecificPolicy(route, supportedPolicyTreeDepth).(map[string]interface{})
+	for k := range ResourceNotificationPolicyRoute().Schema {
+		data.Set(k, packed[k])
+	}
+	data.SetId(policyRouteID(route.ObjectMatchers))
+	return nil
+}
+
+func createNotificationPolicyRoute(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	lock := &meta.(*client).alertingMutex
+	lock.Lock()
+	defer lock.Unlock()
+
+	id, err := updatePolicyRoutes(data, meta, func(routes []interface{}, route interface{}, id string) ([]interface{}, error) {
+		index, err := findPolicyRoute(routes, id)
+		if err != nil {
+			return nil, err
+		}
+		if index >= 0 {
+			return nil, fmt.Errorf("a child route of the root policy already has the matchers %s, import it instead", id)
+		}
+		return append(routes, route), nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(id)
+	return readNotificationPolicyRoute(ctx, data, meta)
+}
+
+func updateNotificationPolicyRoute(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	lock := &meta.(*client).alertingMutex
+	lock.Lock()
+	defer lock.Unlock()
+
+	oldID := data.Id()
+	id, err := updatePolicyRoutes(data, meta, func(routes []interface{}, route interface{}, id string) ([]interface{}, error) {
+		index, err := findPolicyRoute(routes, oldID)
+		if err != nil {
+			return nil, err
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("the notification policy route %s no longer exists", oldID)
+		}
+		if id != oldID {
+			other, err := findPolicyRoute(routes, id)
+			if err != nil {
+				return nil, err
+			}
+			if other >= 0 {
+				return nil, fmt.Errorf("a child route of the root policy already has the matchers %s", id)
+			}
+		}
+		routes[index] = route
+		return routes, nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(id)
+	return readNotificationPolicyRoute(ctx, data, meta)
+}
+
+func deleteNotificationPolicyRoute(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	lock := &meta.(*client).alertingMutex
+	lock.Lock()
+	defer lock.Unlock()
+
+	oldID := data.Id()
+	_, err := updatePolicyRoutes(data, meta, func(routes []interface{}, _ interface{}, _ string) ([]interface{}, error) {
+		index, err := findPolicyRoute(routes, oldID)
+		if err != nil || index < 0 {
+			return routes, err
+		}
+		return append(routes[:index], routes[index+1:]...), nil
+	})
+	return diag.FromErr(err)
+}
+
+// updatePolicyRoutes reads the notification policy tree, replaces the child routes of the root policy with the ones returned by
+// update, and saves the tree. update is called with the configured route and its ID, which are returned. The provenance of the
+// tree is kept. The caller must hold the alerting mutex.
+func updatePolicyRoutes(data *schema.ResourceData, meta interface{}, update func(routes []interface{}, route interface{}, id string) ([]interface{}, error)) (string, error) {
+	client := meta.(*client)
+
+	policy := map[string]interface{}{}
+	for k := range ResourceNotificationPolicyRoute().Schema {
+		policy[k] = data.Get(k)
+	}
+	route, err := unpackSpecificPolicy(policy)
+	if err != nil {
+		return "", err
+	}
+	routeJSON, err := policyRouteToJSON(route)
+	if err != nil {
+		return "", err
+	}
+	id := policyRouteID(route.ObjectMatchers)
+
+	tree, err := client.notificationPolicyTree()
+	if err != nil {
+		return "", err
+	}
+	routes, _ := tree["routes"].([]interface{})
+	if routes, err = update(routes, routeJSON, id); err != nil {
+		return "", err
+	}
+	tree["routes"] = routes
+
+	provenance, _ := tree["provenance"].(string)
+	return id, client.setNotificationPolicyTree(tree, provenance == "")
+}
+
+// findPolicyRoute returns the index of the route of routes that has the matchers of the given ID, or -1.
+func findPolicyRoute(routes []interface{}, id string) (int, error) {
+	matchers, err := parsePolicyRouteID(id)
+	if err != nil {
+		return -1, err
+	}
+	id = policyRouteID(matchers)
+	for i, r := range routes {
+		route, err := policyRouteFromJSON(r)
+		if err != nil {
+			return -1, err
+		}
+		if len(route.ObjectMatchers) > 0 && policyRouteID(route.ObjectMatchers) == id {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
+
+func policyRouteFromJSON(raw interface{}) (gapi.SpecificPolicy, error) {
+	var route gapi.SpecificPolicy
+	routeJSON, err := json.Marshal(raw)
+	if err != nil {
+		return route, err
+	}
+	err = json.Unmarshal(routeJSON, &route)
+	return route, err
+}
+
+func policyRouteToJSON(route gapi.SpecificPolicy) (interface{}, error) {
+	var raw interface{}
+	routeJSON, err := json.Marshal(route)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(routeJSON, &raw)
+	return raw, err
+}
+
+// policyRouteID returns the ID of a route, which is its sorted matchers in the format of Prometheus selectors,
+// such as `{team="backend", severity=~"critical|page"}`.
+func policyRouteID(matchers gapi.Matchers) string {
+	formatted := make([]string, 0, len(matchers))
+	for _, m := range matchers {
+		formatted = append(formatted, m.Name+m.Type.String()+strconv.Quote(m.Value))
+	}
+	sort.Strings(formatted)
+	return "{" + strings.Join(formatted, ", ") + "}"
+}
+
+func parsePolicyRouteID(id string) (gapi.Matchers, error) {
+	remaining := strings.TrimSpace(id)
+	if !strings.HasPrefix(remaining, "{") || !strings.HasSuffix(remaining, "}") {
+		return nil, fmt.Errorf("invalid notification policy route ID %q, it must be the matchers of the route, such as {team=\"backend\"}", id)
+	}
+	remaining = remaining[1 : len(remaining)-1]
+
+	var matchers gapi.Matchers
+	for strings.TrimSpace(remaining) != "" {
+		match := policyRouteMatcherRegexp.FindStringSubmatch(remaining)
+		if match == nil {
+			return nil, fmt.Errorf("invalid notification policy route ID %q: can't parse the matcher at %q", id, remaining)
+		}
+		value, err := strconv.Unquote(match[3])
+		if err != nil {
+			return nil, fmt.Errorf("invalid notification policy route ID %q: %s", id, err)
+		}
+		matcher, err := unpackPolicyMatcher(map[string]interface{}{"label": match[1], "match": match[2], "value": value})
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, matcher)
+		remaining = remaining[len(match[0]):]
+	}
+	return matchers, nil
+}
@@ -0,0 +1,136 @@
+package grafana
+
+import (
+	"fmt"
+	"testing"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccNotificationPolicyRoute_basic(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=9.1.0")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testNotifPolicyRouteCheckDestroy(),
+		Steps: []resource.TestStep{
+			// Test creation, next to a route that isn't managed by the resource.
+			{
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*client).gapi
+					err := client.SetNotificationPolicyTree(&gapi.NotificationPolicyTree{
+						Receiver: "grafana-default-email",
+						GroupBy:  []string{"..."},
+						Routes: []gapi.SpecificPolicy{{
+							Receiver:       "grafana-default-email",
+							ObjectMatchers: gapi.Matchers{{Type: gapi.MatchEqual, Name: "team", Value: "frontend"}},
+						}},
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccExample(t, "resources/grafana_notification_policy_route/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testNotifPolicyRouteCheckTree(`{team="frontend"}`, `{team="backend"}`),
+					resource.TestCheckResourceAttr("grafana_notification_policy_route.backend", "id", `{team="backend"}`),
+					resource.TestCheckResourceAttr("grafana_notification_policy_route.backend", "contact_point", "Backend Team"),
+					resource.TestCheckResourceAttr("grafana_notification_policy_route.backend", "group_by.0", "alertname"),
+					resource.TestCheckResourceAttr("grafana_notification_policy_route.backend", "repeat_interval", "3h"),
+					resource.TestCheckResourceAttr("grafana_notification_policy_route.backend", "policy.0.matcher.0.value", "critical"),
+				),
+			},
+			// Test import.
+			{
+				ResourceName:      "grafana_notification_policy_route.backend",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Test update.
+			{
+				Config: testAccExampleWithReplace(t, "resources/grafana_notification_policy_route/resource.tf", map[string]string{
+					`"3h"`: `"4h"`,
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testNotifPolicyRouteCheckTree(`{team="frontend"}`, `{team="backend"}`),
+					resource.TestCheckResourceAttr("grafana_notification_policy_route.backend", "repeat_interval", "4h"),
+				),
+			},
+			// Test changing the matchers, which updates the route in place.
+			{
+				Config: testAccExampleWithReplace(t, "resources/grafana_notification_policy_route/resource.tf", map[string]string{
+					`value = "backend"`: `value = "platform"`,
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testNotifPolicyRouteCheckTree(`{team="frontend"}`, `{team="platform"}`),
+					resource.TestCheckResourceAttr("grafana_notification_policy_route.backend", "id", `{team="platform"}`),
+				),
+			},
+		},
+	})
+}
+
+func TestPolicyRouteID(t *testing.T) {
+	IsUnitTest(t)
+
+	matchers := gapi.Matchers{
+		{Type: gapi.MatchRegexp, Name: "severity", Value: `critical|"page"`},
+		{Type: gapi.MatchEqual, Name: "team", Value: "backend, api"},
+	}
+	id := policyRouteID(matchers)
+	if expected := `{severity=~"critical|\"page\"", team="backend, api"}`; id != expected {
+		t.Fatalf("expected ID %s, got %s", expected, id)
+	}
+
+	for _, variant := range []string{id, `{team = "backend, api",severity=~"critical|\"page\""}`, ` { severity=~"critical|\"page\"" , team="backend, api" } `} {
+		parsed, err := parsePolicyRouteID(variant)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := policyRouteID(parsed); got != id {
+			t.Errorf("%s: expected ID %s, got %s", variant, id, got)
+		}
+	}
+
+	for _, invalid := range []string{`team="backend"`, `{team=backend}`, `{team=="backend"}`, `{team="backend" severity="page"}`} {
+		if _, err := parsePolicyRouteID(invalid); err == nil {
+			t.Errorf("%s: expected an error", invalid)
+		}
+	}
+}
+
+// testNotifPolicyRouteCheckTree checks the IDs of the child routes of the root policy.
+func testNotifPolicyRouteCheckTree(ids ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		tree, err := testAccProvider.Meta().(*client).notificationPolicyTree()
+		if err != nil {
+			return err
+		}
+		routes, _ := tree["routes"].([]interface{})
+		if len(routes) != len(ids) {
+			return fmt.Errorf("expected %d routes, got %d", len(ids), len(routes))
+		}
+		for i, id := range ids {
+			route, err := policyRouteFromJSON(routes[i])
+			if err != nil {
+				return err
+			}
+			if got := policyRouteID(route.ObjectMatchers); got != id {
+				return fmt.Errorf("expected route %d to be %s, got %s", i, id, got)
+			}
+		}
+		return nil
+	}
+}
+
+func testNotifPolicyRouteCheckDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if err := testNotifPolicyRouteCheckTree(`{team="frontend"}`)(s); err != nil {
+			return err
+		}
+		return testAccProvider.Meta().(*client).gapi.ResetNotificationPolicyTree()
+	}
+}
@@ -2,6 +2,7 @@ package grafana
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
@@ -78,6 +79,102 @@ func TestAccNotificationPolicy_basic(t *testing.T) {
 	})
 }
 
+func TestAccNotificationPolicy_policyTree(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=9.1.0")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testNotifPolicyCheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_notification_policy/_acc_policy_tree_yaml.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testNotifPolicyCheckExists("grafana_notification_policy.my_notification_policy"),
+					resource.TestCheckResourceAttr("grafana_notification_policy.my_notification_policy", "contact_point", ""),
+					resource.TestCheckResourceAttr("grafana_notification_policy.my_notification_policy", "policy.#", "0"),
+					func(s *terraform.State) error {
+						tree, err := testAccProvider.Meta().(*client).notificationPolicyTree()
+						if err != nil {
+							return err
+						}
+						route := tree
+						for depth := 0; depth < 5; depth++ {
+							routes, _ := route["routes"].([]interface{})
+							if len(routes) != 1 {
+								return fmt.Errorf("expected one route at depth %d, got %v", depth+1, route["routes"])
+							}
+							route = routes[0].(map[string]interface{})
+						}
+						if route["continue"] != true {
+							return fmt.Errorf("expected the deepest route to continue, got %v", route)
+						}
+						return nil
+					},
+				),
+			},
+			// Equivalent trees in other formats don't produce a diff
+			{
+				Config: testAccExampleWithReplace(t, "resources/grafana_notification_policy/_acc_policy_tree_yaml.tf", map[string]string{
+					"group_wait: 45s":            "group_wait: 45000ms",
+					"repeat_interval: 60m":       "repeat_interval: 1h",
+					`matchers: [team="backend"]`: `object_matchers: [[team, "=", backend]]`,
+				}),
+				PlanOnly: true,
+			},
+			// Switch to the policy blocks
+			{
+				Config: testAccExample(t, "resources/grafana_notification_policy/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_notification_policy.my_notification_policy", "policy_tree_yaml", ""),
+					resource.TestCheckResourceAttr("grafana_notification_policy.my_notification_policy", "policy.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNotificationPolicy_invalid(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=9.1.0")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Without strict_validation, the contact points and mute timings may be created in the same apply
+				Config:      fmt.Sprintf(testAccNotificationPolicyInvalid, false),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`the notification policy has 2 problem\(s\)`),
+			},
+			{
+				Config:      fmt.Sprintf(testAccNotificationPolicyInvalid, true),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`the notification policy has 4 problem\(s\)`),
+			},
+		},
+	})
+}
+
+const testAccNotificationPolicyInvalid = `
+resource "grafana_notification_policy" "invalid" {
+  contact_point     = "A Nonexistent Contact Point"
+  group_by          = ["..."]
+  group_wait        = "45 seconds"
+  strict_validation = %t
+
+  policy {
+    contact_point = "grafana-default-email"
+    group_by      = ["alertname"]
+    mute_timings  = ["A Nonexistent Mute Timing"]
+    matcher {
+      label = "service"
+      match = "=~"
+      value = "api("
+    }
+  }
+}`
+
 func testNotifPolicyCheckDestroy() resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		client := testAccProvider.Meta().(*client).gapi
@@ -120,3 +217,25 @@ func testNotifPolicyCheckExists(rname string) resource.TestCheckFunc {
 func notifPolicyIsDefault(np gapi.NotificationPolicyTree) bool {
 	return np.Receiver == "grafana-default-email"
 }
+
+func TestNotificationPolicyGroupByRequired(t *testing.T) {
+	IsUnitTest(t)
+
+	for _, tc := range []struct {
+		name        string
+		config      map[string]interface{}
+		expectError bool
+	}{
+		{name: "contact point without group_by", config: map[string]interface{}{"contact_point": "default"}, expectError: true},
+		{name: "group_by without contact point", config: map[string]interface{}{"group_by": []interface{}{"..."}}, expectError: true},
+		{name: "contact point and group_by", config: map[string]interface{}{"contact_point": "default", "group_by": []interface{}{"..."}}},
+		{name: "policy tree", config: map[string]interface{}{"policy_tree_yaml": "receiver: default"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := ResourceNotificationPolicy().Validate(terraform.NewResourceConfigRaw(tc.config))
+			if diags.HasError() != tc.expectError {
+				t.Errorf("expected an error: %t, got %v", tc.expectError, diags)
+			}
+		})
+	}
+}
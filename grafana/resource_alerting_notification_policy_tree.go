@@ -0,0 +1,217 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// This file holds the notification policy trees given as a document, in the `policy_tree_json` and `policy_tree_yaml` attributes
+// of `grafana_notification_policy`. They are normalized to the format of the Grafana API, so that both the Grafana and the
+// Alertmanager formats of the route tree are accepted, and so that documents can be compared semantically.
+
+const (
+	policyTreeFormatJSON = "json"
+	policyTreeFormatYAML = "yaml"
+)
+
+// alertmanagerMatcherRegexp matches one matcher of the Alertmanager `matchers` of a route, such as `team="backend"` or `team=~backend|api`.
+var alertmanagerMatcherRegexp = regexp.MustCompile(`^\s*([^\s=!~,"{}]+)\s*(=~|!~|!=|=)\s*("(?:[^"\\]|\\.)*"|[^,"]*)\s*(?:,|$)`)
+
+var policyTreeDurationKeys = []string{"group_wait", "group_interval", "repeat_interval"}
+
+// parsePolicyTree parses and normalizes a notification policy tree document. The document can also be a full Alertmanager
+// configuration, with the tree under its `route` key.
+func parsePolicyTree(document, format string) (map[string]interface{}, error) {
+	var raw interface{}
+	var err error
+	if format == policyTreeFormatJSON {
+		err = json.Unmarshal([]byte(document), &raw)
+	} else {
+		err = yaml.Unmarshal([]byte(document), &raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the policy tree: %s", err)
+	}
+
+	root, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the policy tree must be an object")
+	}
+	if route, ok := root["route"].(map[string]interface{}); ok && root["receiver"] == nil {
+		root = route
+	}
+	return normalizePolicyRoute(root)
+}
+
+// formatPolicyTree returns the document of a normalized policy tree.
+func formatPolicyTree(tree map[string]interface{}, format string) (string, error) {
+	var document []byte
+	var err error
+	if format == policyTreeFormatJSON {
+		document, err = json.Marshal(tree)
+	} else {
+		document, err = yaml.Marshal(tree)
+	}
+	return string(document), err
+}
+
+// policyTreesEqual tells whether two policy tree documents are semantically equal.
+func policyTreesEqual(a, b, format string) bool {
+	treeA, errA := parsePolicyTree(a, format)
+	treeB, errB := parsePolicyTree(b, format)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return reflect.DeepEqual(treeA, treeB)
+}
+
+// normalizePolicyRoute converts a route, and its child routes, to the format of the Grafana API. The matchers of the Alertmanager
+// format (`matchers`, `match` and `match_re`) are converted to sorted `object_matchers`, the durations are formatted the way Grafana
+// returns them, and the empty values are removed. The result only holds JSON types.
+func normalizePolicyRoute(route map[string]interface{}) (map[string]interface{}, error) {
+	matchers, err := policyRouteObjectMatchers(route)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := map[string]interface{}{}
+	for k, v := range route {
+		switch k {
+		case "provenance", "object_matchers", "matchers", "match", "match_re":
+			continue
+		case "routes":
+			children, ok := v.([]interface{})
+			if !ok && v != nil {
+				return nil, fmt.Errorf("`routes` must be a list of routes")
+			}
+			normalizedChildren := make([]interface{}, 0, len(children))
+			for _, c := range children {
+				child, ok := c.(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("`routes` must be a list of routes")
+				}
+				normalizedChild, err := normalizePolicyRoute(child)
+				if err != nil {
+					return nil, err
+				}
+				normalizedChildren = append(normalizedChildren, normalizedChild)
+			}
+			v = normalizedChildren
+		}
+		if !policyTreeValueIsEmpty(v) {
+			normalized[k] = v
+		}
+	}
+	for _, k := range policyTreeDurationKeys {
+		if s, ok := normalized[k].(string); ok {
+			if duration, err := parsePrometheusDuration(s); err == nil {
+				normalized[k] = formatPrometheusDuration(duration)
+			}
+		}
+	}
+	if len(matchers) > 0 {
+		normalized["object_matchers"] = matchers
+	}
+
+	// Round-trip through JSON, so that the YAML types are the same as the JSON ones
+	normalizedJSON, err := json.Marshal(normalized)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]interface{}{}
+	err = json.Unmarshal(normalizedJSON, &result)
+	return result, err
+}
+
+// policyRouteObjectMatchers returns all the matchers of a route, in the `object_matchers` format of the Grafana API, sorted.
+func policyRouteObjectMatchers(route map[string]interface{}) ([]interface{}, error) {
+	var matchers [][3]string
+
+	objectMatchers, _ := route["object_matchers"].([]interface{})
+	for _, m := range objectMatchers {
+		matcher, _ := m.([]interface{})
+		if len(matcher) != 3 {
+			return nil, fmt.Errorf("`object_matchers` must be a list of [label, operator, value] lists, got %v", m)
+		}
+		var parts [3]string
+		for i := range parts {
+			parts[i] = fmt.Sprint(matcher[i])
+		}
+		matchers = append(matchers, parts)
+	}
+
+	stringMatchers, _ := route["matchers"].([]interface{})
+	for _, m := range stringMatchers {
+		parsed, err := parseAlertmanagerMatchers(fmt.Sprint(m))
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, parsed...)
+	}
+
+	for key, operator := range map[string]string{"match": "=", "match_re": "=~"} {
+		labels, _ := route[key].(map[string]interface{})
+		for label, value := range labels {
+			matchers = append(matchers, [3]string{label, operator, fmt.Sprint(value)})
+		}
+	}
+
+	sort.Slice(matchers, func(i, j int) bool {
+		return strings.Join(matchers[i][:], "\x00") < strings.Join(matchers[j][:], "\x00")
+	})
+	result := make([]interface{}, 0, len(matchers))
+	for _, m := range matchers {
+		result = append(result, []interface{}{m[0], m[1], m[2]})
+	}
+	return result, nil
+}
+
+// parseAlertmanagerMatchers parses an item of the Alertmanager `matchers` of a route. It can hold several matchers,
+// separated by commas and optionally enclosed in braces, such as `{team="backend", severity=~"critical|page"}`.
+func parseAlertmanagerMatchers(s string) ([][3]string, error) {
+	remaining := strings.TrimSpace(s)
+	if strings.HasPrefix(remaining, "{") && strings.HasSuffix(remaining, "}") {
+		remaining = remaining[1 : len(remaining)-1]
+	}
+
+	var matchers [][3]string
+	for strings.TrimSpace(remaining) != "" {
+		match := alertmanagerMatcherRegexp.FindStringSubmatch(remaining)
+		if match == nil {
+			return nil, fmt.Errorf("invalid matcher %q", s)
+		}
+		value := strings.TrimSpace(match[3])
+		if strings.HasPrefix(value, `"`) {
+			var err error
+			if value, err = strconv.Unquote(value); err != nil {
+				return nil, fmt.Errorf("invalid matcher %q: %s", s, err)
+			}
+		}
+		matchers = append(matchers, [3]string{match[1], match[2], value})
+		remaining = remaining[len(match[0]):]
+	}
+	return matchers, nil
+}
+
+func policyTreeValueIsEmpty(v interface{}) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case bool:
+		return !value
+	case []interface{}:
+		return len(value) == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package grafana
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePolicyTree(t *testing.T) {
+	IsUnitTest(t)
+
+	alertmanagerYAML := `
+global:
+  resolve_timeout: 5m
+route:
+  receiver: default
+  group_by: [alertname]
+  group_wait: 30000ms
+  continue: false
+  routes:
+    - receiver: backend
+      matchers:
+        - '{team="backend", severity=~"critical|page"}'
+        - env!=dev
+      match:
+        region: eu
+      routes:
+        - match_re:
+            service: api|web
+          repeat_interval: 90m
+`
+	grafanaJSON := `{
+		"receiver": "default",
+		"group_by": ["alertname"],
+		"group_wait": "30s",
+		"provenance": "api",
+		"routes": [{
+			"receiver": "backend",
+			"object_matchers": [["team", "=", "backend"], ["region", "=", "eu"], ["severity", "=~", "critical|page"], ["env", "!=", "dev"]],
+			"routes": [{"object_matchers": [["service", "=~", "api|web"]], "repeat_interval": "1h30m", "mute_time_intervals": []}]
+		}]
+	}`
+
+	fromYAML, err := parsePolicyTree(alertmanagerYAML, policyTreeFormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJSON, err := parsePolicyTree(grafanaJSON, policyTreeFormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromYAML, fromJSON) {
+		t.Errorf("expected the trees to be equal:\nyaml: %v\njson: %v", fromYAML, fromJSON)
+	}
+
+	expected := map[string]interface{}{
+		"receiver":   "default",
+		"group_by":   []interface{}{"alertname"},
+		"group_wait": "30s",
+		"routes": []interface{}{map[string]interface{}{
+			"receiver": "backend",
+			"object_matchers": []interface{}{
+				[]interface{}{"env", "!=", "dev"},
+				[]interface{}{"region", "=", "eu"},
+				[]interface{}{"severity", "=~", "critical|page"},
+				[]interface{}{"team", "=", "backend"},
+			},
+			"routes": []interface{}{map[string]interface{}{
+				"object_matchers": []interface{}{[]interface{}{"service", "=~", "api|web"}},
+				"repeat_interval": "1h30m",
+			}},
+		}},
+	}
+	if !reflect.DeepEqual(fromJSON, expected) {
+		t.Errorf("unexpected tree:\ngot:  %v\nwant: %v", fromJSON, expected)
+	}
+
+	for _, format := range []string{policyTreeFormatJSON, policyTreeFormatYAML} {
+		document, err := formatPolicyTree(fromJSON, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !policyTreesEqual(document, grafanaJSON, format) {
+			t.Errorf("%s: expected the formatted tree to be equal to the original one:\n%s", format, document)
+		}
+	}
+	if policyTreesEqual(grafanaJSON, `{"receiver": "other"}`, policyTreeFormatJSON) {
+		t.Error("expected different trees not to be equal")
+	}
+
+	for _, invalid := range []string{
+		`[]`,
+		`{"receiver": "a", "routes": {"receiver": "b"}}`,
+		`{"receiver": "a", "routes": [{"object_matchers": [["team", "="]]}]}`,
+		`{"receiver": "a", "routes": [{"matchers": ["team"]}]}`,
+	} {
+		if _, err := parsePolicyTree(invalid, policyTreeFormatJSON); err == nil {
+			t.Errorf("%s: expected an error", invalid)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// This file holds the plan time validation of `grafana_notification_policy`. The matchers and durations must be valid and, with
+// `strict_validation`, the contact points and mute timings referenced by the policies must exist in Grafana or be planned in the same
+// run. Otherwise, Grafana rejects the whole tree at apply time with an error that doesn't tell which policy is wrong.
+//
+// The resources are only planned before the notification policy if it references them, so the references aren't checked by default:
+// names written as literals would fail the plan. They are reported if Grafana rejects the tree instead.
+
+// policyDiffReader is implemented by *schema.ResourceDiff.
+type policyDiffReader interface {
+	Get(key string) interface{}
+	NewValueKnown(key string) bool
+}
+
+// notificationPolicyReferences holds the places where each contact point or mute timing is referenced.
+type notificationPolicyReferences map[string][]string
+
+func (r notificationPolicyReferences) add(name, path string) {
+	r[name] = append(r[name], path)
+}
+
+func customizeNotificationPolicyDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	attributes := append([]string{"contact_point", "group_wait", "group_interval", "repeat_interval", "policy", "strict_validation"}, policyTreeAttributes...)
+	if d.Id() != "" && !d.HasChanges(attributes...) {
+		return nil
+	}
+
+	tree, location, err := notificationPolicyDiffTree(d)
+	if err != nil || tree == nil {
+		// The errors of the documents are reported by their validation
+		return nil
+	}
+
+	contactPoints := notificationPolicyReferences{}
+	muteTimings := notificationPolicyReferences{}
+	problems := checkPolicyRoute(tree, location, "", contactPoints, muteTimings)
+
+	strict := d.Get("strict_validation").(bool)
+	if client, ok := meta.(*client); ok && strict && client.gapi != nil && (len(contactPoints) > 0 || len(muteTimings) > 0) {
+		referenceProblems, err := checkPolicyReferences(client, contactPoints, muteTimings, true)
+		if err != nil {
+			return fmt.Errorf("error checking the contact points and mute timings of the notification policy: %w", err)
+		}
+		problems = append(problems, referenceProblems...)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("the notification policy has %d problem(s):\n  - %s", len(problems), strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// notificationPolicyDiffTree returns the planned tree in the format of the Grafana API, with the name of the attribute that holds the
+// child routes, which is used to locate the problems. The values that aren't known yet are left out, and the tree is nil if the
+// whole tree isn't known yet.
+func notificationPolicyDiffTree(d policyDiffReader) (map[string]interface{}, string, error) {
+	for _, attr := range policyTreeAttributes {
+		if !d.NewValueKnown(attr) {
+			return nil, "", nil
+		}
+		if document := d.Get(attr).(string); document != "" {
+			format := policyTreeFormatJSON
+			if attr == "policy_tree_yaml" {
+				format = policyTreeFormatYAML
+			}
+			tree, err := parsePolicyTree(document, format)
+			return tree, "routes", err
+		}
+	}
+
+	tree := map[string]interface{}{}
+	for _, k := range append([]string{"contact_point"}, policyTreeDurationKeys...) {
+		if d.NewValueKnown(k) {
+			tree[policyRouteKey(k)] = d.Get(k)
+		}
+	}
+	if d.NewValueKnown("policy") {
+		tree["policy"] = policyRoutesFromDiff(d, "policy")
+	}
+	return tree, "policy", nil
+}
+
+// policyRoutesFromDiff converts the `policy` blocks at the given path to the format of the Grafana API, keeping their children
+// in `policy`.
+func policyRoutesFromDiff(d policyDiffReader, path string) []interface{} {
+	policies, _ := d.Get(path).([]interface{})
+	routes := make([]interface{}, 0, len(policies))
+	for i := range policies {
+		policyPath := fmt.Sprintf("%s.%d", path, i)
+		route := map[string]interface{}{}
+		for _, k := range append([]string{"contact_point"}, policyTreeDurationKeys...) {
+			if d.NewValueKnown(policyPath + "." + k) {
+				route[policyRouteKey(k)] = d.Get(policyPath + "." + k)
+			}
+		}
+
+		if d.NewValueKnown(policyPath + ".mute_timings") {
+			var muteTimings []interface{}
+			raw, _ := d.Get(policyPath + ".mute_timings").([]interface{})
+			for j, name := range raw {
+				if d.NewValueKnown(fmt.Sprintf("%s.mute_timings.%d", policyPath, j)) {
+					muteTimings = append(muteTimings, name)
+				}
+			}
+			route["mute_time_intervals"] = muteTimings
+		}
+
+		if d.NewValueKnown(policyPath + ".matcher") {
+			var matchers []interface{}
+			raw, _ := d.Get(policyPath + ".matcher").([]interface{})
+			for j := range raw {
+				matcherPath := fmt.Sprintf("%s.matcher.%d", policyPath, j)
+				if d.NewValueKnown(matcherPath+".match") && d.NewValueKnown(matcherPath+".value") {
+					matchers = append(matchers, []interface{}{d.Get(matcherPath + ".label"), d.Get(matcherPath + ".match"), d.Get(matcherPath + ".value")})
+				}
+			}
+			route["object_matchers"] = matchers
+		}
+
+		if _, ok := policies[i].(map[string]interface{})["policy"]; ok && d.NewValueKnown(policyPath+".policy") {
+			route["policy"] = policyRoutesFromDiff(d, policyPath+".policy")
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+func policyRouteKey(attr string) string {
+	if attr == "contact_point" {
+		return "receiver"
+	}
+	return attr
+}
+
+// checkPolicyRoute checks the matchers and durations of a route and of its children, and collects the contact points and mute
+// timings that they reference. The children are read from the given key, which is also used in the path of the problems.
+func checkPolicyRoute(route map[string]interface{}, childKey, path string, contactPoints, muteTimings notificationPolicyReferences) []string {
+	location := path
+	if location == "" {
+		location = "root policy"
+	}
+
+	var problems []string
+	if receiver, _ := route["receiver"].(string); receiver != "" {
+		contactPoints.add(receiver, location)
+	}
+	muteTimeIntervals, _ := route["mute_time_intervals"].([]interface{})
+	for _, name := range muteTimeIntervals {
+		if name, _ := name.(string); name != "" {
+			muteTimings.add(name, location)
+		}
+	}
+
+	matchers, _ := route["object_matchers"].([]interface{})
+	for _, m := range matchers {
+		matcher, _ := m.([]interface{})
+		if len(matcher) != 3 {
+			continue
+		}
+		label, operator, value := fmt.Sprint(matcher[0]), fmt.Sprint(matcher[1]), fmt.Sprint(matcher[2])
+		switch operator {
+		case "=", "!=":
+		case "=~", "!~":
+			// Alertmanager anchors the regular expressions of the matchers
+			if _, err := regexp.Compile("^(?:" + value + ")$"); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: invalid regular expression in the matcher %s%s%q: %s", location, label, operator, value, err))
+			}
+		default:
+			problems = append(problems, fmt.Sprintf("%s: unknown match operator %q in the matcher on %q, it must be one of =, !=, =~ or !~", location, operator, label))
+		}
+	}
+
+	for _, k := range policyTreeDurationKeys {
+		if duration, _ := route[k].(string); duration != "" {
+			if _, err := parsePrometheusDuration(duration); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: invalid %s: %s", location, k, err))
+			}
+		}
+	}
+
+	children, _ := route[childKey].([]interface{})
+	for i, c := range children {
+		if child, ok := c.(map[string]interface{}); ok {
+			childPath := fmt.Sprintf("%s.%d", childKey, i)
+			if path != "" {
+				childPath = path + "." + childPath
+			}
+			problems = append(problems, checkPolicyRoute(child, childKey, childPath, contactPoints, muteTimings)...)
+		}
+	}
+	return problems
+}
+
+// checkPolicyReferences returns the referenced contact points and mute timings that don't exist in Grafana. With includePlanned,
+// the ones planned in this run are considered to exist.
+func checkPolicyReferences(client *client, contactPoints, muteTimings notificationPolicyReferences, includePlanned bool) ([]string, error) {
+	existingContactPoints := map[string]bool{}
+	if len(contactPoints) > 0 {
+		points, err := client.gapi.ContactPoints()
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range points {
+			existingContactPoints[p.Name] = true
+		}
+	}
+	existingMuteTimings := map[string]bool{}
+	if len(muteTimings) > 0 {
+		timings, err := client.gapi.MuteTimings()
+		if err != nil {
+			return nil, err
+		}
+		for _, mt := range timings {
+			existingMuteTimings[mt.Name] = true
+		}
+	}
+
+	plannedContactPointNames, plannedMuteTimingNames := map[string]bool{}, map[string]bool{}
+	if includePlanned {
+		plannedContactPointNames, plannedMuteTimingNames = client.plannedNamesOf(plannedContactPoints), client.plannedNamesOf(plannedMuteTimings)
+	}
+	problems := missingPolicyReferences("contact point", contactPoints, existingContactPoints, plannedContactPointNames, "grafana_contact_point")
+	problems = append(problems, missingPolicyReferences("mute timing", muteTimings, existingMuteTimings, plannedMuteTimingNames, "grafana_mute_timing")...)
+	return problems, nil
+}
+
+// notificationPolicyReferenceError adds the referenced contact points and mute timings that don't exist to an error of Grafana
+// rejecting a tree in the format of the API.
+func notificationPolicyReferenceError(client *client, tree map[string]interface{}, err error) error {
+	contactPoints := notificationPolicyReferences{}
+	muteTimings := notificationPolicyReferences{}
+	checkPolicyRoute(tree, "routes", "", contactPoints, muteTimings)
+	problems, checkErr := checkPolicyReferences(client, contactPoints, muteTimings, false)
+	if checkErr != nil || len(problems) == 0 {
+		return err
+	}
+	return fmt.Errorf("%w\nthe notification policy references %d missing contact point(s) or mute timing(s):\n  - %s", err, len(problems), strings.Join(problems, "\n  - "))
+}
+
+func missingPolicyReferences(kind string, references notificationPolicyReferences, existing, planned map[string]bool, resourceType string) []string {
+	names := make([]string, 0, len(references))
+	for name := range references {
+		if !existing[name] && !planned[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	problems := make([]string, 0, len(names))
+	for _, name := range names {
+		problems = append(problems, fmt.Sprintf(
+			"%s: the %s %q doesn't exist. If it's created by a `%s` resource, reference its `name` attribute or add it to `depends_on` so that it's created first",
+			strings.Join(references[name], ", "), kind, name, resourceType,
+		))
+	}
+	return problems
+}
@@ -0,0 +1,192 @@
+package grafana
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// testPolicyDiffReader reads the configured attributes of a notification policy, some of which aren't known yet.
+type testPolicyDiffReader struct {
+	*schema.ResourceData
+	unknown map[string]bool
+}
+
+func (r testPolicyDiffReader) NewValueKnown(key string) bool {
+	return !r.unknown[key]
+}
+
+func TestNotificationPolicyDiffTree(t *testing.T) {
+	IsUnitTest(t)
+
+	data := schema.TestResourceDataRaw(t, ResourceNotificationPolicy().Schema, map[string]interface{}{
+		"contact_point": "default",
+		"group_by":      []interface{}{"..."},
+		"group_wait":    "30s",
+		"policy": []interface{}{
+			map[string]interface{}{
+				"contact_point": "backend",
+				"group_by":      []interface{}{"alertname"},
+				"mute_timings":  []interface{}{"weekends", "planned"},
+				"matcher": []interface{}{
+					map[string]interface{}{"label": "team", "match": "=~", "value": "backend|api"},
+				},
+				"policy": []interface{}{
+					map[string]interface{}{
+						"contact_point":   "unknown",
+						"group_by":        []interface{}{"alertname"},
+						"repeat_interval": "1x",
+					},
+				},
+			},
+		},
+	})
+	reader := testPolicyDiffReader{ResourceData: data, unknown: map[string]bool{
+		"policy.0.mute_timings.1":           true,
+		"policy.0.policy.0.contact_point":   true,
+		"policy.0.policy.0.repeat_interval": false,
+	}}
+
+	tree, childKey, err := notificationPolicyDiffTree(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if childKey != "policy" {
+		t.Errorf("expected the children in policy, got %s", childKey)
+	}
+
+	contactPoints := notificationPolicyReferences{}
+	muteTimings := notificationPolicyReferences{}
+	problems := checkPolicyRoute(tree, childKey, "", contactPoints, muteTimings)
+
+	if expected := (notificationPolicyReferences{"default": {"root policy"}, "backend": {"policy.0"}}); !reflect.DeepEqual(contactPoints, expected) {
+		t.Errorf("expected the contact points %v, got %v", expected, contactPoints)
+	}
+	if expected := (notificationPolicyReferences{"weekends": {"policy.0"}}); !reflect.DeepEqual(muteTimings, expected) {
+		t.Errorf("expected the mute timings %v, got %v", expected, muteTimings)
+	}
+	if len(problems) != 1 || !strings.HasPrefix(problems[0], "policy.0.policy.0: invalid repeat_interval") {
+		t.Errorf("expected a problem with the repeat_interval of policy.0.policy.0, got %v", problems)
+	}
+}
+
+func TestCheckPolicyRoute(t *testing.T) {
+	IsUnitTest(t)
+
+	tree, err := parsePolicyTree(`
+receiver: default
+group_interval: 5x
+routes:
+  - receiver: backend
+    mute_time_intervals: [weekends]
+    object_matchers: [[team, "==", backend], [service, "=~", "api("]]
+    routes:
+      - receiver: backend
+        matchers: ['env!~"dev|staging"']
+        group_wait: 1m30s
+`, policyTreeFormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contactPoints := notificationPolicyReferences{}
+	muteTimings := notificationPolicyReferences{}
+	problems := checkPolicyRoute(tree, "routes", "", contactPoints, muteTimings)
+
+	expectedProblems := []string{
+		"routes.0: invalid regular expression in the matcher service=~\"api(\"",
+		"routes.0: unknown match operator \"==\" in the matcher on \"team\"",
+		"root policy: invalid group_interval",
+	}
+	if len(problems) != len(expectedProblems) {
+		t.Fatalf("expected %d problems, got %v", len(expectedProblems), problems)
+	}
+	for _, expected := range expectedProblems {
+		found := false
+		for _, problem := range problems {
+			found = found || strings.HasPrefix(problem, expected)
+		}
+		if !found {
+			t.Errorf("expected a problem starting with %q, got %v", expected, problems)
+		}
+	}
+
+	if expected := (notificationPolicyReferences{"default": {"root policy"}, "backend": {"routes.0", "routes.0.routes.0"}}); !reflect.DeepEqual(contactPoints, expected) {
+		t.Errorf("expected the contact points %v, got %v", expected, contactPoints)
+	}
+	if expected := (notificationPolicyReferences{"weekends": {"routes.0"}}); !reflect.DeepEqual(muteTimings, expected) {
+		t.Errorf("expected the mute timings %v, got %v", expected, muteTimings)
+	}
+}
+
+func TestMissingPolicyReferences(t *testing.T) {
+	IsUnitTest(t)
+
+	references := notificationPolicyReferences{
+		"existing": {"root policy"},
+		"planned":  {"policy.0"},
+		"missing":  {"policy.1", "policy.1.policy.0"},
+	}
+	problems := missingPolicyReferences("contact point", references, map[string]bool{"existing": true}, map[string]bool{"planned": true}, "grafana_contact_point")
+	if len(problems) != 1 || !strings.HasPrefix(problems[0], `policy.1, policy.1.policy.0: the contact point "missing" doesn't exist`) {
+		t.Errorf("expected the missing contact point to be reported, got %v", problems)
+	}
+}
+
+func TestNotificationPolicyReferenceError(t *testing.T) {
+	IsUnitTest(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/provisioning/contact-points":
+			fmt.Fprint(w, `[{"name": "default"}]`)
+		case "/api/v1/provisioning/mute-timings":
+			fmt.Fprint(w, `[]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	gapiClient, err := gapi.New(server.URL, gapi.Config{Client: server.Client()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Planned names aren't created yet when the tree is rejected
+	c := &client{gapi: gapiClient, plannedNames: map[string]map[string]bool{plannedContactPoints: {"planned": true}}}
+
+	tree, err := parsePolicyTree(`
+receiver: default
+routes:
+  - receiver: planned
+    mute_time_intervals: [weekends]
+`, policyTreeFormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	apiErr := errors.New("status: 400, body: invalid object specification")
+	err = notificationPolicyReferenceError(c, tree, apiErr)
+	if !errors.Is(err, apiErr) {
+		t.Errorf("expected the error of the API to be wrapped, got %v", err)
+	}
+	for _, expected := range []string{`routes.0: the contact point "planned" doesn't exist`, `routes.0: the mute timing "weekends" doesn't exist`} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected the error to contain %q, got %v", expected, err)
+		}
+	}
+
+	tree, err = parsePolicyTree(`receiver: default`, policyTreeFormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := notificationPolicyReferenceError(c, tree, apiErr); err != apiErr {
+		t.Errorf("expected the error of the API without missing references, got %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package grafana
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceAlertRule() *schema.Resource {
+	ruleSchema := alertRuleSchema()
+	ruleSchema["uid"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: "The unique identifier of the alert rule. It's generated by Grafana if not set.",
+	}
+	ruleSchema["folder_uid"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "The UID of the folder that the rule belongs to.",
+	}
+	ruleSchema["rule_group"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "The name of the rule group that the rule belongs to. The group is created with the first rule added to it.",
+	}
+	ruleSchema["org_id"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: "The ID of the org to which the rule belongs.",
+	}
+	ruleSchema["disable_provenance"] = disableProvenanceSchema()
+
+	return &schema.Resource{
+		Description: `
+Manages a single Grafana Alerting rule. Unlike ` + "`grafana_rule_group`" + `, several ` + "`grafana_alert_rule`" + ` resources (and rules managed outside of Terraform) can share an evaluation group.
+
+The evaluation interval of the group isn't managed by this resource. New groups are evaluated at the default interval of Grafana,
+which can be changed with the ` + "`grafana_rule_group_interval`" + ` resource. Don't manage the same group with ` + "`grafana_rule_group`" + `, which would remove the other rules of the group.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/alerting/alerting-rules)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/alerting_provisioning/#alert-rules)
+
+This resource requires Grafana 9.1.0 or later.
+`,
+		CreateContext: createAlertRule,
+		ReadContext:   readAlertRule,
+		UpdateContext: updateAlertRule,
+		DeleteContext: deleteAlertRule,
+		CustomizeDiff: customdiff.All(
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateAlertRuleKind(alertRuleResourceMap(d), d.GetRawConfig())
+			},
+			forceNewOnDisableProvenance,
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		SchemaVersion: 0,
+		Schema:        ruleSchema,
+	}
+}
+
+func readAlertRule(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	rule, err := client.alertRule(data.Id())
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "status: 404") {
+			log.Printf("[WARN] removing alert rule %s from state because it no longer exists in grafana", data.Id())
+			data.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	packed, err := packAlertRule(rule, alertRuleResourceMap(data))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for k, v := range packed.(map[string]interface{}) {
+		if err := data.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	data.Set("folder_uid", rule.FolderUID)
+	data.Set("rule_group", rule.RuleGroup)
+	data.Set("org_id", rule.OrgID)
+	data.Set("disable_provenance", rule.Provenance == "")
+	data.SetId(rule.UID)
+
+	return nil
+}
+
+func createAlertRule(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	lock := &meta.(*client).alertingMutex
+	client := meta.(*client)
+
+	rule, err := unpackAlertRule(alertRuleResourceMap(data), data.Get("rule_group").(string), data.Get("folder_uid").(string), data.Get("org_id").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	uid, err := client.newAlertRule(&rule, data.Get("disable_provenance").(bool))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(uid)
+	return readAlertRule(ctx, data, meta)
+}
+
+func updateAlertRule(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	lock := &meta.(*client).alertingMutex
+	client := meta.(*client)
+
+	rule, err := unpackAlertRule(alertRuleResourceMap(data), data.Get("rule_group").(string), data.Get("folder_uid").(string), data.Get("org_id").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rule.UID = data.Id()
+
+	lock.Lock()
+	defer lock.Unlock()
+	if err := client.updateAlertRule(&rule, data.Get("disable_provenance").(bool)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return readAlertRule(ctx, data, meta)
+}
+
+func deleteAlertRule(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	lock := &meta.(*client).alertingMutex
+	client := meta.(*client).gapi
+
+	lock.Lock()
+	defer lock.Unlock()
+	if err := client.DeleteAlertRule(data.Id()); err != nil && !strings.HasPrefix(err.Error(), "status: 404") {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// alertRuleResourceMap returns the attributes of a `grafana_alert_rule` in the form of a `rule` block of `grafana_rule_group`.
+func alertRuleResourceMap(data interface{ Get(string) interface{} }) map[string]interface{} {
+	raw := map[string]interface{}{}
+	for k := range alertRuleSchema() {
+		raw[k] = data.Get(k)
+	}
+	return raw
+}
@@ -0,0 +1,365 @@
+package grafana
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// This file holds the typed expression blocks of the `data` stages of alert rules. They are compiled
+// to the model of the server-side expressions data source (`__expr__`, UID `-100`), and read back from it.
+
+const expressionDatasourceUID = "-100"
+
+// ruleExpressionTypes maps the expression blocks to the `type` of their model.
+var ruleExpressionTypes = map[string]string{
+	"reduce":            "reduce",
+	"math":              "math",
+	"threshold":         "threshold",
+	"resample":          "resample",
+	"classic_condition": "classic_conditions",
+}
+
+var (
+	expressionReducers       = []string{"mean", "min", "max", "sum", "count", "last"}
+	expressionEvaluatorTypes = []string{"gt", "lt", "within_range", "outside_range"}
+	// Classic conditions have their own reducers, the mean is `avg`
+	classicConditionReducers = []string{"avg", "min", "max", "sum", "count", "last", "median", "diff", "diff_abs", "percent_diff", "percent_diff_abs", "count_non_null"}
+)
+
+// ruleExpressionBlockNames returns the names of the expression blocks, sorted.
+func ruleExpressionBlockNames() []string {
+	names := make([]string, 0, len(ruleExpressionTypes))
+	for name := range ruleExpressionTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func ruleExpressionSchemas() map[string]*schema.Schema {
+	evaluator := &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		MaxItems:    1,
+		Description: "The condition applied to the values.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(expressionEvaluatorTypes, false),
+					Description:  allowedValuesDescription("The type of evaluator", expressionEvaluatorTypes),
+				},
+				"params": {
+					Type:        schema.TypeList,
+					Required:    true,
+					MinItems:    1,
+					MaxItems:    2,
+					Description: "The threshold, or the bounds of the range for the range evaluators.",
+					Elem:        &schema.Schema{Type: schema.TypeFloat},
+				},
+			},
+		},
+	}
+
+	return map[string]*schema.Schema{
+		"reduce": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Reduces the time series of a stage to single numbers.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"expression": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The `ref_id` of the stage to reduce.",
+					},
+					"reducer": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(expressionReducers, false),
+						Description:  allowedValuesDescription("The reduction function", expressionReducers),
+					},
+					"mode": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "",
+						ValidateFunc: validation.StringInSlice([]string{"", "dropNN", "replaceNN"}, false),
+						Description:  "How non-numeric values are handled: `\"\"` (strict, the result is NaN), `dropNN` to drop them, or `replaceNN` to replace them by `replace_with`.",
+					},
+					"replace_with": {
+						Type:        schema.TypeFloat,
+						Optional:    true,
+						Description: "The value replacing non-numeric values, with the `replaceNN` mode.",
+					},
+				},
+			},
+		},
+		"math": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Computes a math expression on the results of other stages.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"expression": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The math expression, referencing other stages as `$A`. For example: `$A > 3`.",
+					},
+				},
+			},
+		},
+		"threshold": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Compares the values of a stage to a threshold.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"expression": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The `ref_id` of the stage to compare.",
+					},
+					"evaluator": evaluator,
+				},
+			},
+		},
+		"resample": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Changes the time stamps of the time series of a stage to a consistent interval.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"expression": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The `ref_id` of the stage to resample.",
+					},
+					"window": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The duration of the resampled intervals. For example: `10s`.",
+					},
+					"downsampler": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice([]string{"mean", "min", "max", "sum", "last"}, false),
+						Description:  "The reduction function used when there are several values in an interval.",
+					},
+					"upsampler": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice([]string{"pad", "backfilling", "fillna"}, false),
+						Description:  "How intervals without values are filled: `pad` with the previous value, `backfilling` with the next value, or `fillna` with NaN.",
+					},
+				},
+			},
+		},
+		"classic_condition": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Evaluates conditions the same way as legacy dashboard alerts.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"condition": {
+						Type:        schema.TypeList,
+						Required:    true,
+						MinItems:    1,
+						Description: "The conditions, combined with their `operator`.",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"query": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "The `ref_id` of the stage the condition applies to.",
+								},
+								"reducer": {
+									Type:         schema.TypeString,
+									Required:     true,
+									ValidateFunc: validation.StringInSlice(classicConditionReducers, false),
+									Description:  allowedValuesDescription("The reduction function applied to the time series of the stage", classicConditionReducers),
+								},
+								"evaluator": evaluator,
+								"operator": {
+									Type:         schema.TypeString,
+									Optional:     true,
+									Default:      "and",
+									ValidateFunc: validation.StringInSlice([]string{"and", "or"}, false),
+									Description:  "How the condition is combined with the previous ones: `and` or `or`.",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ruleDataExpressionBlock returns the name of the expression block set in a `data` stage, if any.
+// It fails if several blocks are set, or if a block and `model` are both set.
+func ruleDataExpressionBlock(row map[string]interface{}) (string, error) {
+	var set []string
+	for _, name := range ruleExpressionBlockNames() {
+		if blocks, _ := row[name].([]interface{}); len(blocks) > 0 {
+			set = append(set, name)
+		}
+	}
+	if model, _ := row["model"].(string); model != "" {
+		set = append(set, "model")
+	}
+
+	switch len(set) {
+	case 0:
+		return "", fmt.Errorf("data stage %q: one of `model`, `%s` must be set", row["ref_id"], strings.Join(ruleExpressionBlockNames(), "`, `"))
+	case 1:
+		if set[0] == "model" {
+			return "", nil
+		}
+		return set[0], nil
+	}
+	return "", fmt.Errorf("data stage %q: only one of `%s` can be set", row["ref_id"], strings.Join(set, "`, `"))
+}
+
+// compileRuleExpression returns the model of an expression block.
+func compileRuleExpression(name string, block map[string]interface{}, refID string) map[string]interface{} {
+	model := map[string]interface{}{
+		"refId":      refID,
+		"type":       ruleExpressionTypes[name],
+		"datasource": map[string]interface{}{"type": "__expr__", "uid": expressionDatasourceUID},
+	}
+
+	switch name {
+	case "reduce":
+		model["expression"] = block["expression"]
+		model["reducer"] = block["reducer"]
+		if mode := block["mode"].(string); mode != "" {
+			settings := map[string]interface{}{"mode": mode}
+			if mode == "replaceNN" {
+				settings["replaceWithValue"] = block["replace_with"]
+			}
+			model["settings"] = settings
+		}
+	case "math":
+		model["expression"] = block["expression"]
+	case "threshold":
+		model["expression"] = block["expression"]
+		model["conditions"] = []interface{}{
+			map[string]interface{}{"evaluator": compileRuleExpressionEvaluator(block["evaluator"])},
+		}
+	case "resample":
+		model["expression"] = block["expression"]
+		model["window"] = block["window"]
+		model["downsampler"] = block["downsampler"]
+		model["upsampler"] = block["upsampler"]
+	case "classic_condition":
+		var conditions []interface{}
+		for _, c := range block["condition"].([]interface{}) {
+			condition := c.(map[string]interface{})
+			conditions = append(conditions, map[string]interface{}{
+				"type":      "query",
+				"query":     map[string]interface{}{"params": []interface{}{condition["query"]}},
+				"reducer":   map[string]interface{}{"type": condition["reducer"], "params": []interface{}{}},
+				"evaluator": compileRuleExpressionEvaluator(condition["evaluator"]),
+				"operator":  map[string]interface{}{"type": condition["operator"]},
+			})
+		}
+		model["conditions"] = conditions
+	}
+	return model
+}
+
+func compileRuleExpressionEvaluator(raw interface{}) map[string]interface{} {
+	evaluator := raw.([]interface{})[0].(map[string]interface{})
+	return map[string]interface{}{
+		"type":   evaluator["type"],
+		"params": evaluator["params"],
+	}
+}
+
+// decompileRuleExpression returns the expression block for a model, or false if the model
+// doesn't match the block.
+func decompileRuleExpression(name string, model map[string]interface{}) (map[string]interface{}, bool) {
+	if model["type"] != ruleExpressionTypes[name] {
+		return nil, false
+	}
+	str := func(key string) string {
+		s, _ := model[key].(string)
+		return s
+	}
+
+	switch name {
+	case "reduce":
+		block := map[string]interface{}{
+			"expression": str("expression"),
+			"reducer":    str("reducer"),
+			"mode":       "",
+		}
+		if settings, ok := model["settings"].(map[string]interface{}); ok {
+			block["mode"], _ = settings["mode"].(string)
+			if value, ok := settings["replaceWithValue"].(float64); ok {
+				block["replace_with"] = value
+			}
+		}
+		return block, true
+	case "math":
+		return map[string]interface{}{"expression": str("expression")}, true
+	case "threshold":
+		conditions, _ := model["conditions"].([]interface{})
+		if len(conditions) != 1 {
+			return nil, false
+		}
+		condition, _ := conditions[0].(map[string]interface{})
+		return map[string]interface{}{
+			"expression": str("expression"),
+			"evaluator":  decompileRuleExpressionEvaluator(condition["evaluator"]),
+		}, true
+	case "resample":
+		return map[string]interface{}{
+			"expression":  str("expression"),
+			"window":      str("window"),
+			"downsampler": str("downsampler"),
+			"upsampler":   str("upsampler"),
+		}, true
+	case "classic_condition":
+		conditions, _ := model["conditions"].([]interface{})
+		var packed []interface{}
+		for _, c := range conditions {
+			condition, _ := c.(map[string]interface{})
+			query, _ := condition["query"].(map[string]interface{})
+			queryParams, _ := query["params"].([]interface{})
+			reducer, _ := condition["reducer"].(map[string]interface{})
+			operator, _ := condition["operator"].(map[string]interface{})
+			packedCondition := map[string]interface{}{
+				"query":     "",
+				"reducer":   reducer["type"],
+				"evaluator": decompileRuleExpressionEvaluator(condition["evaluator"]),
+				"operator":  operator["type"],
+			}
+			if len(queryParams) > 0 {
+				packedCondition["query"] = queryParams[0]
+			}
+			packed = append(packed, packedCondition)
+		}
+		return map[string]interface{}{"condition": packed}, true
+	}
+	return nil, false
+}
+
+func decompileRuleExpressionEvaluator(raw interface{}) []interface{} {
+	evaluator, _ := raw.(map[string]interface{})
+	params, _ := evaluator["params"].([]interface{})
+	return []interface{}{map[string]interface{}{
+		"type":   evaluator["type"],
+		"params": params,
+	}}
+}
@@ -0,0 +1,165 @@
+package grafana
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestRuleExpressions(t *testing.T) {
+	IsUnitTest(t)
+
+	evaluator := func(evaluatorType string, params ...interface{}) []interface{} {
+		return []interface{}{map[string]interface{}{"type": evaluatorType, "params": params}}
+	}
+
+	cases := []struct {
+		name          string
+		block         map[string]interface{}
+		expectedModel string
+	}{
+		{
+			name:          "reduce",
+			block:         map[string]interface{}{"expression": "A", "reducer": "last", "mode": ""},
+			expectedModel: `{"datasource":{"type":"__expr__","uid":"-100"},"expression":"A","reducer":"last","refId":"B","type":"reduce"}`,
+		},
+		{
+			name:          "reduce",
+			block:         map[string]interface{}{"expression": "A", "reducer": "mean", "mode": "replaceNN", "replace_with": 1.5},
+			expectedModel: `{"datasource":{"type":"__expr__","uid":"-100"},"expression":"A","reducer":"mean","refId":"B","settings":{"mode":"replaceNN","replaceWithValue":1.5},"type":"reduce"}`,
+		},
+		{
+			name:          "math",
+			block:         map[string]interface{}{"expression": "$A + 3"},
+			expectedModel: `{"datasource":{"type":"__expr__","uid":"-100"},"expression":"$A + 3","refId":"B","type":"math"}`,
+		},
+		{
+			name:          "threshold",
+			block:         map[string]interface{}{"expression": "A", "evaluator": evaluator("within_range", 1.0, 2.0)},
+			expectedModel: `{"conditions":[{"evaluator":{"params":[1,2],"type":"within_range"}}],"datasource":{"type":"__expr__","uid":"-100"},"expression":"A","refId":"B","type":"threshold"}`,
+		},
+		{
+			name:          "resample",
+			block:         map[string]interface{}{"expression": "A", "window": "10s", "downsampler": "max", "upsampler": "pad"},
+			expectedModel: `{"datasource":{"type":"__expr__","uid":"-100"},"downsampler":"max","expression":"A","refId":"B","type":"resample","upsampler":"pad","window":"10s"}`,
+		},
+		{
+			name: "classic_condition",
+			block: map[string]interface{}{"condition": []interface{}{
+				map[string]interface{}{"query": "A", "reducer": "last", "operator": "and", "evaluator": evaluator("gt", 3.0)},
+				map[string]interface{}{"query": "A", "reducer": "avg", "operator": "or", "evaluator": evaluator("lt", 1.0)},
+			}},
+			expectedModel: `{"conditions":[` +
+				`{"evaluator":{"params":[3],"type":"gt"},"operator":{"type":"and"},"query":{"params":["A"]},"reducer":{"params":[],"type":"last"},"type":"query"},` +
+				`{"evaluator":{"params":[1],"type":"lt"},"operator":{"type":"or"},"query":{"params":["A"]},"reducer":{"params":[],"type":"avg"},"type":"query"}` +
+				`],"datasource":{"type":"__expr__","uid":"-100"},"refId":"B","type":"classic_conditions"}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			modelJSON, err := json.Marshal(compileRuleExpression(tc.name, tc.block, "B"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(modelJSON) != tc.expectedModel {
+				t.Errorf("expected model:\n%s\ngot:\n%s", tc.expectedModel, modelJSON)
+			}
+
+			// The model is read back the way it's returned by the API
+			var model map[string]interface{}
+			if err := json.Unmarshal(modelJSON, &model); err != nil {
+				t.Fatal(err)
+			}
+			block, ok := decompileRuleExpression(tc.name, model)
+			if !ok {
+				t.Fatal("expected the model to match the expression block")
+			}
+			if !reflect.DeepEqual(block, tc.block) {
+				t.Errorf("expected block %v, got %v", tc.block, block)
+			}
+		})
+	}
+
+	if _, ok := decompileRuleExpression("reduce", map[string]interface{}{"type": "math"}); ok {
+		t.Error("expected a math model not to match the reduce block")
+	}
+}
+
+func TestRuleDataExpressions(t *testing.T) {
+	IsUnitTest(t)
+
+	row := func(model string, blocks ...string) map[string]interface{} {
+		r := map[string]interface{}{
+			"ref_id":              "B",
+			"datasource_uid":      "",
+			"query_type":          "",
+			"model":               model,
+			"relative_time_range": []interface{}{},
+		}
+		for _, name := range ruleExpressionBlockNames() {
+			r[name] = []interface{}{}
+		}
+		for _, name := range blocks {
+			r[name] = []interface{}{map[string]interface{}{"expression": "$A > 1"}}
+		}
+		return r
+	}
+
+	for _, invalid := range []map[string]interface{}{
+		row(""),
+		row(`{}`, "math"),
+		row("", "math", "reduce"),
+	} {
+		if _, err := unpackRuleData([]interface{}{invalid}); err == nil {
+			t.Errorf("expected an error for %v", invalid)
+		}
+	}
+	if _, err := unpackRuleData([]interface{}{row(`{"refId": "B"}`)}); err == nil {
+		t.Error("expected an error for a model without datasource_uid")
+	}
+
+	// Expression blocks are read back as blocks only if they are in the state
+	queries, err := unpackRuleData([]interface{}{row("", "math")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queries[0].DatasourceUID != expressionDatasourceUID {
+		t.Errorf("expected datasource_uid %s, got %s", expressionDatasourceUID, queries[0].DatasourceUID)
+	}
+	packed, err := packRuleData(queries, []interface{}{row("", "math")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	packedRow := packed.([]interface{})[0].(map[string]interface{})
+	if packedRow["model"] != "" || !reflect.DeepEqual(packedRow["math"], []interface{}{map[string]interface{}{"expression": "$A > 1"}}) {
+		t.Errorf("expected the math block to be packed, got %v", packedRow)
+	}
+	packed, err = packRuleData(queries, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packedRow = packed.([]interface{})[0].(map[string]interface{})
+	if packedRow["model"] == "" || packedRow["math"] != nil {
+		t.Errorf("expected the model to be packed, got %v", packedRow)
+	}
+}
+
+func TestRuleExpressionSchemas(t *testing.T) {
+	IsUnitTest(t)
+
+	if names := ruleExpressionBlockNames(); !reflect.DeepEqual(names, []string{"classic_condition", "math", "reduce", "resample", "threshold"}) {
+		t.Errorf("unexpected expression blocks: %v", names)
+	}
+
+	condition := ruleExpressionSchemas()["classic_condition"].Elem.(*schema.Resource).Schema["condition"].Elem.(*schema.Resource)
+	validateReducer := condition.Schema["reducer"].ValidateFunc
+	if _, errs := validateReducer("avg", "reducer"); len(errs) > 0 {
+		t.Errorf("expected avg to be a valid classic condition reducer, got %v", errs)
+	}
+	if _, errs := validateReducer("mean", "reducer"); len(errs) == 0 {
+		t.Error("expected mean to be an invalid classic condition reducer")
+	}
+}
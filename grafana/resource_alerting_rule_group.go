@@ -3,13 +3,16 @@ package grafana
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"reflect"
 	"strings"
 	"time"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -18,6 +21,8 @@ func ResourceRuleGroup() *schema.Resource {
 		Description: `
 Manages Grafana Alerting rule groups.
 
+This resource manages all the rules of the group, and removes the rules that aren't configured. To share a group between several teams, use ` + "`grafana_alert_rule`" + ` and ` + "`grafana_rule_group_interval`" + ` instead.
+
 * [Official documentation](https://grafana.com/docs/grafana/latest/alerting/alerting-rules)
 * [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/alerting_provisioning/#alert-rules)
 
@@ -27,6 +32,7 @@ This resource requires Grafana 9.1.0 or later.
 		ReadContext:   readAlertRuleGroup,
 		UpdateContext: updateAlertRuleGroup,
 		DeleteContext: deleteAlertRuleGroup,
+		CustomizeDiff: customdiff.All(customizeRuleGroupDiff, forceNewOnDisableProvenance),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -56,129 +62,219 @@ This resource requires Grafana 9.1.0 or later.
 				ForceNew:    true,
 				Description: "The ID of the org to which the group belongs.",
 			},
+			"disable_provenance": disableProvenanceSchema(),
 			"rule": {
 				Type:        schema.TypeList,
 				Required:    true,
 				Description: "The rules within the group.",
 				MinItems:    1,
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"uid": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "The unique identifier of the alert rule.",
-						},
-						"name": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "The name of the alert rule.",
-						},
-						"for": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     0,
-							Description: "The amount of time for which the rule must be breached for the rule to be considered to be Firing. Before this time has elapsed, the rule is only considered to be Pending.",
-						},
-						"no_data_state": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "NoData",
-							Description: "Describes what state to enter when the rule's query returns No Data. Options are OK, NoData, and Alerting.",
-						},
-						"exec_err_state": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "Alerting",
-							Description: "Describes what state to enter when the rule's query is invalid and the rule cannot be executed. Options are OK, Error, and Alerting.",
-						},
-						"condition": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "The `ref_id` of the query node in the `data` field to use as the alert condition.",
-						},
-						"data": {
-							Type:             schema.TypeList,
-							Required:         true,
-							MinItems:         1,
-							Description:      "A sequence of stages that describe the contents of the rule.",
-							DiffSuppressFunc: diffSuppressJSON,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"ref_id": {
-										Type:        schema.TypeString,
-										Required:    true,
-										Description: "A unique string to identify this query stage within a rule.",
-									},
-									"datasource_uid": {
-										Type:        schema.TypeString,
-										Required:    true,
-										Description: "The UID of the datasource being queried, or \"-100\" if this stage is an expression stage.",
-									},
-									"query_type": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Default:     "",
-										Description: "An optional identifier for the type of query being executed.",
-									},
-									"model": {
-										Required:    true,
-										Type:        schema.TypeString,
-										Description: "Custom JSON data to send to the specified datasource when querying.",
-									},
-									"relative_time_range": {
-										Type:        schema.TypeList,
-										Required:    true,
-										Description: "The time range, relative to when the query is executed, across which to query.",
-										MaxItems:    1,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"from": {
-													Type:        schema.TypeInt,
-													Required:    true,
-													Description: "The number of seconds in the past, relative to when the rule is evaluated, at which the time range begins.",
-												},
-												"to": {
-													Type:        schema.TypeInt,
-													Required:    true,
-													Description: "The number of seconds in the past, relative to when the rule is evaluated, at which the time range ends.",
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-						"labels": {
-							Type:        schema.TypeMap,
-							Optional:    true,
-							Default:     map[string]interface{}{},
-							Description: "Key-value pairs to attach to the alert rule that can be used in matching, grouping, and routing.",
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-						},
-						"annotations": {
-							Type:        schema.TypeMap,
-							Optional:    true,
-							Default:     map[string]interface{}{},
-							Description: "Key-value pairs of metadata to attach to the alert rule that may add user-defined context, but cannot be used for matching, grouping, or routing.",
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-						},
+					Schema: alertRuleSchema(),
+				},
+			},
+		},
+	}
+}
+
+// alertRuleSchema returns the schema of an alert rule, shared by the rules of `grafana_rule_group` and by `grafana_alert_rule`.
+func alertRuleSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"uid": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The unique identifier of the alert rule.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name of the alert rule.",
+		},
+		"for": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     0,
+			Description: "The amount of time for which the rule must be breached for the rule to be considered to be Firing. Before this time has elapsed, the rule is only considered to be Pending.",
+		},
+		"no_data_state": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "NoData",
+			Description: "Describes what state to enter when the rule's query returns No Data. Options are OK, NoData, and Alerting.",
+		},
+		"exec_err_state": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "Alerting",
+			Description: "Describes what state to enter when the rule's query is invalid and the rule cannot be executed. Options are OK, Error, and Alerting.",
+		},
+		"condition": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The `ref_id` of the query node in the `data` field to use as the alert condition. Required for alert rules, it can't be set on recording rules.",
+		},
+		"record": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Makes the rule a recording rule, which writes the result of a query to a new time series instead of alerting. `condition`, `for`, `no_data_state`, `exec_err_state` and `notification_settings` can't be set on recording rules. This requires Grafana 11 or later.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"metric": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The name of the metric to write to.",
+					},
+					"from": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The `ref_id` of the query node in the `data` field to write to the metric.",
+					},
+					"target_datasource_uid": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Computed:    true,
+						Description: "The UID of the Prometheus data source to write the metric to. Defaults to the data source configured for recording rules in Grafana.",
+					},
+				},
+			},
+		},
+		"data": {
+			Type:             schema.TypeList,
+			Required:         true,
+			MinItems:         1,
+			Description:      "A sequence of stages that describe the contents of the rule. Each stage sets either `model`, or one of the expression blocks (`reduce`, `math`, `threshold`, `resample` or `classic_condition`).",
+			DiffSuppressFunc: diffSuppressJSON,
+			Elem: &schema.Resource{
+				Schema: ruleDataSchema(),
+			},
+		},
+		"is_paused": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Set to true to pause the evaluation of the alert rule.",
+		},
+		"notification_settings": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Sends the notifications of the rule to a contact point directly, instead of routing them with the notification policies. This requires Grafana 10.4 or later.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"contact_point": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The name of the contact point to send the notifications to.",
+					},
+					"group_by": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Description: "The labels by which alerts are grouped in notifications. Use `...` to group by all labels.",
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+					"group_wait": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The time to wait to buffer alerts of the same group before sending the first notification. Defaults to the value of the root notification policy.",
+					},
+					"group_interval": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The minimum time interval between two notifications for the same group. Defaults to the value of the root notification policy.",
+					},
+					"repeat_interval": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The minimum time interval for re-sending a notification if an alert is still firing. Defaults to the value of the root notification policy.",
+					},
+					"mute_timings": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Description: "The names of the mute timings during which the notifications are muted.",
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+		"labels": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Default:     map[string]interface{}{},
+			Description: "Key-value pairs to attach to the alert rule that can be used in matching, grouping, and routing.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"annotations": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Default:     map[string]interface{}{},
+			Description: "Key-value pairs of metadata to attach to the alert rule that may add user-defined context, but cannot be used for matching, grouping, or routing.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+func ruleDataSchema() map[string]*schema.Schema {
+	dataSchema := map[string]*schema.Schema{
+		"ref_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "A unique string to identify this query stage within a rule.",
+		},
+		"datasource_uid": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The UID of the datasource being queried, or \"-100\" if this stage is an expression stage. Required with `model`, it is always \"-100\" with the expression blocks.",
+		},
+		"query_type": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "An optional identifier for the type of query being executed.",
+		},
+		"model": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Custom JSON data to send to the specified datasource when querying. Conflicts with the expression blocks.",
+		},
+		"relative_time_range": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Computed:    true,
+			Description: "The time range, relative to when the query is executed, across which to query. Required with `model`, it is ignored by the expression blocks.",
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"from": {
+						Type:        schema.TypeInt,
+						Required:    true,
+						Description: "The number of seconds in the past, relative to when the rule is evaluated, at which the time range begins.",
+					},
+					"to": {
+						Type:        schema.TypeInt,
+						Required:    true,
+						Description: "The number of seconds in the past, relative to when the rule is evaluated, at which the time range ends.",
 					},
 				},
 			},
 		},
 	}
+	for name, s := range ruleExpressionSchemas() {
+		dataSchema[name] = s
+	}
+	return dataSchema
 }
 
 func readAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gapi
+	client := meta.(*client)
 
 	key := unpackGroupID(data.Id())
 
-	group, err := client.AlertRuleGroup(key.folderUID, key.name)
+	group, err := client.alertRuleGroup(key.folderUID, key.name)
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "status: 404") {
 			log.Printf("[WARN] removing rule group %s/%s from state because it no longer exists in grafana", key.folderUID, key.name)
@@ -197,7 +293,7 @@ func readAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta int
 }
 
 func createAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gapi
+	client := meta.(*client)
 
 	group, err := unpackRuleGroup(data)
 	if err != nil {
@@ -205,7 +301,7 @@ func createAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta i
 	}
 	key := ruleKeyFromGroup(group)
 
-	if err = client.SetAlertRuleGroup(group); err != nil {
+	if err = client.setAlertRuleGroup(&group, data.Get("disable_provenance").(bool)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -214,7 +310,7 @@ func createAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta i
 }
 
 func updateAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gapi
+	client := meta.(*client)
 
 	group, err := unpackRuleGroup(data)
 	if err != nil {
@@ -222,7 +318,7 @@ func updateAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta i
 	}
 	key := ruleKeyFromGroup(group)
 
-	if err = client.SetAlertRuleGroup(group); err != nil {
+	if err = client.setAlertRuleGroup(&group, data.Get("disable_provenance").(bool)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -249,6 +345,68 @@ func deleteAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta i
 	return diag.Diagnostics{}
 }
 
+func customizeRuleGroupDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	rules, _ := d.Get("rule").([]interface{})
+	configRules := d.GetRawConfig().GetAttr("rule")
+	for i, r := range rules {
+		config := cty.DynamicVal
+		if configRules.IsKnown() && !configRules.IsNull() && i < configRules.LengthInt() {
+			config = configRules.Index(cty.NumberIntVal(int64(i)))
+		}
+		if err := validateAlertRuleKind(r.(map[string]interface{}), config); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateAlertRuleKind checks that a rule has the attributes of its kind: alert rules need a `condition`, and recording rules
+// (with a `record` block) can't have the alerting attributes. config is the configuration of the rule, which tells the
+// attributes that are set apart from the ones that have their default value.
+func validateAlertRuleKind(rule map[string]interface{}, config cty.Value) error {
+	name := rule["name"]
+	records, _ := rule["record"].([]interface{})
+	if len(records) == 0 || records[0] == nil {
+		if configured := attributeIsConfigured(config, "condition"); configured != nil && !*configured {
+			return fmt.Errorf("rule %q: `condition` is required for alert rules", name)
+		}
+		return nil
+	}
+
+	for _, attr := range []string{"condition", "for", "no_data_state", "exec_err_state", "notification_settings"} {
+		if configured := attributeIsConfigured(config, attr); configured != nil && *configured {
+			return fmt.Errorf("rule %q: `%s` can't be set on recording rules", name, attr)
+		}
+	}
+
+	from, _ := records[0].(map[string]interface{})["from"].(string)
+	data, _ := rule["data"].([]interface{})
+	refIDs := make([]string, 0, len(data))
+	for _, d := range data {
+		refID, _ := d.(map[string]interface{})["ref_id"].(string)
+		if refID == from || refID == "" || from == "" {
+			// Unknown values are checked at apply time
+			return nil
+		}
+		refIDs = append(refIDs, refID)
+	}
+	return fmt.Errorf("rule %q: `record.from` is %q, it must be the `ref_id` of one of the `data` stages: %s", name, from, strings.Join(refIDs, ", "))
+}
+
+// attributeIsConfigured tells whether an attribute (or a block) is set in the configuration of an object,
+// or returns nil if it's not known yet.
+func attributeIsConfigured(config cty.Value, attr string) *bool {
+	if !config.IsKnown() || config.IsNull() || !config.Type().IsObjectType() || !config.Type().HasAttribute(attr) {
+		return nil
+	}
+	value := config.GetAttr(attr)
+	if !value.IsKnown() {
+		return nil
+	}
+	configured := !value.IsNull() && !(value.CanIterateElements() && value.LengthInt() == 0)
+	return &configured
+}
+
 func diffSuppressJSON(k, oldValue, newValue string, data *schema.ResourceData) bool {
 	var o, n interface{}
 	d := json.NewDecoder(strings.NewReader(oldValue))
@@ -262,40 +420,68 @@ func diffSuppressJSON(k, oldValue, newValue string, data *schema.ResourceData) b
 	return reflect.DeepEqual(o, n)
 }
 
-func packRuleGroup(g gapi.RuleGroup, data *schema.ResourceData) error {
+func packRuleGroup(g alertRuleGroup, data *schema.ResourceData) error {
 	data.Set("name", g.Title)
 	data.Set("folder_uid", g.FolderUID)
 	data.Set("interval_seconds", g.Interval)
-	rules := make([]interface{}, 0, len(g.Rules))
+
 	for _, r := range g.Rules {
 		data.Set("org_id", r.OrgID)
-		packed, err := packAlertRule(r)
-		if err != nil {
-			return err
-		}
-		rules = append(rules, packed)
+		data.Set("disable_provenance", r.Provenance == "")
+	}
+
+	// The rules in the state tell which data stages are configured with expression blocks
+	priorRules, _ := data.Get("rule").([]interface{})
+	rules, err := packAlertRules(g.Rules, priorRules)
+	if err != nil {
+		return err
 	}
 	data.Set("rule", rules)
 	return nil
 }
 
-func unpackRuleGroup(data *schema.ResourceData) (gapi.RuleGroup, error) {
+// packAlertRules returns the `rule` blocks of the rules of a group. Each rule is packed with the rule of priorRules
+// that has the same uid, or else with the rule at the same index.
+func packAlertRules(rules []alertRule, priorRules []interface{}) ([]interface{}, error) {
+	priorRulesByUID := map[string]map[string]interface{}{}
+	for _, r := range priorRules {
+		if rule, ok := r.(map[string]interface{}); ok && rule["uid"] != "" {
+			priorRulesByUID[rule["uid"].(string)] = rule
+		}
+	}
+
+	packed := make([]interface{}, 0, len(rules))
+	for i, r := range rules {
+		prior, ok := priorRulesByUID[r.UID]
+		if !ok && i < len(priorRules) {
+			prior, _ = priorRules[i].(map[string]interface{})
+		}
+		rule, err := packAlertRule(r, prior)
+		if err != nil {
+			return nil, err
+		}
+		packed = append(packed, rule)
+	}
+	return packed, nil
+}
+
+func unpackRuleGroup(data *schema.ResourceData) (alertRuleGroup, error) {
 	group := data.Get("name").(string)
 	folder := data.Get("folder_uid").(string)
 	interval := data.Get("interval_seconds").(int)
 	packedRules := data.Get("rule").([]interface{})
 	orgID := data.Get("org_id").(int)
 
-	rules := make([]gapi.AlertRule, 0, len(packedRules))
+	rules := make([]alertRule, 0, len(packedRules))
 	for i := range packedRules {
 		rule, err := unpackAlertRule(packedRules[i], group, folder, orgID)
 		if err != nil {
-			return gapi.RuleGroup{}, err
+			return alertRuleGroup{}, err
 		}
 		rules = append(rules, rule)
 	}
 
-	return gapi.RuleGroup{
+	return alertRuleGroup{
 		Title:     group,
 		FolderUID: folder,
 		Interval:  int64(interval),
@@ -303,60 +489,128 @@ func unpackRuleGroup(data *schema.ResourceData) (gapi.RuleGroup, error) {
 	}, nil
 }
 
-func packAlertRule(r gapi.AlertRule) (interface{}, error) {
-	data, err := packRuleData(r.Data)
+// packAlertRule returns the `rule` block of an alert rule. The data stages that have an expression block in prior,
+// the rule currently in the state, are packed as expression blocks. prior may be nil.
+func packAlertRule(r alertRule, prior map[string]interface{}) (interface{}, error) {
+	priorData, _ := prior["data"].([]interface{})
+	data, err := packRuleData(r.Data, priorData)
 	if err != nil {
 		return nil, err
 	}
 	json := map[string]interface{}{
-		"uid":            r.UID,
-		"name":           r.Title,
-		"for":            r.For,
-		"no_data_state":  string(r.NoDataState),
-		"exec_err_state": string(r.ExecErrState),
-		"condition":      r.Condition,
-		"labels":         r.Labels,
-		"annotations":    r.Annotations,
-		"data":           data,
+		"uid":                   r.UID,
+		"name":                  r.Title,
+		"for":                   r.For,
+		"no_data_state":         string(r.NoDataState),
+		"exec_err_state":        string(r.ExecErrState),
+		"condition":             r.Condition,
+		"labels":                r.Labels,
+		"annotations":           r.Annotations,
+		"data":                  data,
+		"is_paused":             r.IsPaused,
+		"notification_settings": packNotificationSettings(r.NotificationSettings),
+	}
+	if r.Record != nil {
+		// The alerting attributes of recording rules are read as their defaults, since they can't be configured
+		json["record"] = []interface{}{map[string]interface{}{
+			"metric":                r.Record.Metric,
+			"from":                  r.Record.From,
+			"target_datasource_uid": r.Record.TargetDatasourceUID,
+		}}
+		json["for"] = "0"
+		json["no_data_state"] = "NoData"
+		json["exec_err_state"] = "Alerting"
 	}
 	return json, nil
 }
 
-func unpackAlertRule(raw interface{}, groupName string, folderUID string, orgID int) (gapi.AlertRule, error) {
+func unpackAlertRule(raw interface{}, groupName string, folderUID string, orgID int) (alertRule, error) {
 	json := raw.(map[string]interface{})
 	data, err := unpackRuleData(json["data"])
 	if err != nil {
-		return gapi.AlertRule{}, err
-	}
-
-	return gapi.AlertRule{
-		UID:          json["uid"].(string),
-		Title:        json["name"].(string),
-		FolderUID:    folderUID,
-		RuleGroup:    groupName,
-		OrgID:        int64(orgID),
-		ExecErrState: gapi.ExecErrState(json["exec_err_state"].(string)),
-		NoDataState:  gapi.NoDataState(json["no_data_state"].(string)),
-		For:          json["for"].(string),
-		Data:         data,
-		Condition:    json["condition"].(string),
-		Labels:       unpackMap(json["labels"]),
-		Annotations:  unpackMap(json["annotations"]),
-	}, nil
+		return alertRule{}, err
+	}
+
+	rule := alertRule{
+		AlertRule: gapi.AlertRule{
+			UID:          json["uid"].(string),
+			Title:        json["name"].(string),
+			FolderUID:    folderUID,
+			RuleGroup:    groupName,
+			OrgID:        int64(orgID),
+			ExecErrState: gapi.ExecErrState(json["exec_err_state"].(string)),
+			NoDataState:  gapi.NoDataState(json["no_data_state"].(string)),
+			For:          json["for"].(string),
+			Data:         data,
+			Condition:    json["condition"].(string),
+			Labels:       unpackMap(json["labels"]),
+			Annotations:  unpackMap(json["annotations"]),
+		},
+		IsPaused:             json["is_paused"].(bool),
+		NotificationSettings: unpackNotificationSettings(json["notification_settings"]),
+	}
+	if records, _ := json["record"].([]interface{}); len(records) > 0 && records[0] != nil {
+		record := records[0].(map[string]interface{})
+		rule.Record = &alertRuleRecord{
+			Metric:              record["metric"].(string),
+			From:                record["from"].(string),
+			TargetDatasourceUID: record["target_datasource_uid"].(string),
+		}
+		rule.Condition = ""
+		rule.For = "0s"
+		rule.NoDataState = ""
+		rule.ExecErrState = ""
+	}
+	return rule, nil
 }
 
-func packRuleData(queries []*gapi.AlertQuery) (interface{}, error) {
+func packNotificationSettings(settings *alertRuleNotificationSettings) []interface{} {
+	if settings == nil {
+		return nil
+	}
+	return []interface{}{map[string]interface{}{
+		"contact_point":   settings.Receiver,
+		"group_by":        settings.GroupBy,
+		"group_wait":      settings.GroupWait,
+		"group_interval":  settings.GroupInterval,
+		"repeat_interval": settings.RepeatInterval,
+		"mute_timings":    settings.MuteTimeIntervals,
+	}}
+}
+
+func unpackNotificationSettings(raw interface{}) *alertRuleNotificationSettings {
+	list, _ := raw.([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return nil
+	}
+	json := list[0].(map[string]interface{})
+	return &alertRuleNotificationSettings{
+		Receiver:          json["contact_point"].(string),
+		GroupBy:           listToStringSlice(json["group_by"].([]interface{})),
+		GroupWait:         json["group_wait"].(string),
+		GroupInterval:     json["group_interval"].(string),
+		RepeatInterval:    json["repeat_interval"].(string),
+		MuteTimeIntervals: listToStringSlice(json["mute_timings"].([]interface{})),
+	}
+}
+
+func packRuleData(queries []*gapi.AlertQuery, prior []interface{}) (interface{}, error) {
+	priorExpressionBlocks := map[string]string{}
+	for _, p := range prior {
+		row, _ := p.(map[string]interface{})
+		if refID, ok := row["ref_id"].(string); ok {
+			if name, err := ruleDataExpressionBlock(row); err == nil && name != "" {
+				priorExpressionBlocks[refID] = name
+			}
+		}
+	}
+
 	result := []interface{}{}
 	for i := range queries {
 		if queries[i] == nil {
 			continue
 		}
 
-		model, err := json.Marshal(queries[i].Model)
-		if err != nil {
-			return nil, err
-		}
-
 		data := map[string]interface{}{}
 		data["ref_id"] = queries[i].RefID
 		data["datasource_uid"] = queries[i].DatasourceUID
@@ -365,7 +619,22 @@ func packRuleData(queries []*gapi.AlertQuery) (interface{}, error) {
 		timeRange["from"] = int(queries[i].RelativeTimeRange.From)
 		timeRange["to"] = int(queries[i].RelativeTimeRange.To)
 		data["relative_time_range"] = []interface{}{timeRange}
-		data["model"] = string(model)
+
+		model, _ := queries[i].Model.(map[string]interface{})
+		if name, ok := priorExpressionBlocks[queries[i].RefID]; ok && queries[i].DatasourceUID == expressionDatasourceUID {
+			if block, ok := decompileRuleExpression(name, model); ok {
+				data[name] = []interface{}{block}
+				data["model"] = ""
+				result = append(result, data)
+				continue
+			}
+		}
+
+		modelJSON, err := json.Marshal(queries[i].Model)
+		if err != nil {
+			return nil, err
+		}
+		data["model"] = string(modelJSON)
 		result = append(result, data)
 	}
 	return result, nil
@@ -382,16 +651,32 @@ func unpackRuleData(raw interface{}) ([]*gapi.AlertQuery, error) {
 			QueryType:     row["query_type"].(string),
 			DatasourceUID: row["datasource_uid"].(string),
 		}
-		if rtr, ok := row["relative_time_range"]; ok {
-			listShim := rtr.([]interface{})
-			rtr := listShim[0].(map[string]interface{})
-			stage.RelativeTimeRange = gapi.RelativeTimeRange{
-				From: time.Duration(rtr["from"].(int)),
-				To:   time.Duration(rtr["to"].(int)),
-			}
+
+		expressionBlock, err := ruleDataExpressionBlock(row)
+		if err != nil {
+			return nil, err
+		}
+		if expressionBlock != "" {
+			stage.DatasourceUID = expressionDatasourceUID
+			stage.Model = compileRuleExpression(expressionBlock, row[expressionBlock].([]interface{})[0].(map[string]interface{}), stage.RefID)
+			result = append(result, stage)
+			continue
+		}
+
+		if stage.DatasourceUID == "" {
+			return nil, fmt.Errorf("data stage %q: `datasource_uid` must be set with `model`", stage.RefID)
+		}
+		listShim, _ := row["relative_time_range"].([]interface{})
+		if len(listShim) == 0 {
+			return nil, fmt.Errorf("data stage %q: `relative_time_range` must be set with `model`", stage.RefID)
+		}
+		rtr := listShim[0].(map[string]interface{})
+		stage.RelativeTimeRange = gapi.RelativeTimeRange{
+			From: time.Duration(rtr["from"].(int)),
+			To:   time.Duration(rtr["to"].(int)),
 		}
 		var decodedModelJSON interface{}
-		err := json.Unmarshal([]byte(row["model"].(string)), &decodedModelJSON)
+		err = json.Unmarshal([]byte(row["model"].(string)), &decodedModelJSON)
 		if err != nil {
 			return nil, err
 		}
@@ -415,7 +700,7 @@ type alertRuleGroupKey struct {
 	name      string
 }
 
-func ruleKeyFromGroup(g gapi.RuleGroup) alertRuleGroupKey {
+func ruleKeyFromGroup(g alertRuleGroup) alertRuleGroupKey {
 	return alertRuleGroupKey{
 		folderUID: g.FolderUID,
 		name:      g.Title,
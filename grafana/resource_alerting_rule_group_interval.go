@@ -0,0 +1,113 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceRuleGroupInterval() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages the evaluation interval of a Grafana Alerting rule group whose rules are managed by ` + "`grafana_alert_rule`" + ` resources or outside of Terraform.
+
+The rule group must exist, so this resource should depend on at least one of the rules of the group. Deleting this resource leaves the interval unchanged.
+The rules of the group keep their provenance, so rules created with ` + "`disable_provenance`" + ` stay editable in the Grafana UI.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/alerting/alerting-rules)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/alerting_provisioning/#alert-rules)
+
+This resource requires Grafana 9.1.0 or later.
+`,
+		CreateContext: setRuleGroupInterval,
+		ReadContext:   readRuleGroupInterval,
+		UpdateContext: setRuleGroupInterval,
+		DeleteContext: deleteRuleGroupInterval,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"folder_uid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The UID of the folder that the group belongs to.",
+			},
+			"rule_group": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the rule group.",
+			},
+			"interval_seconds": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The interval, in seconds, at which all rules in the group are evaluated. If a group contains many rules, the rules are evaluated sequentially.",
+			},
+		},
+	}
+}
+
+func readRuleGroupInterval(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client).gapi
+
+	key := unpackGroupID(data.Id())
+	group, err := client.AlertRuleGroup(key.folderUID, key.name)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "status: 404") {
+			log.Printf("[WARN] removing rule group interval %s/%s from state because the group no longer exists in grafana", key.folderUID, key.name)
+			data.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	data.Set("folder_uid", group.FolderUID)
+	data.Set("rule_group", group.Title)
+	data.Set("interval_seconds", group.Interval)
+
+	return nil
+}
+
+func setRuleGroupInterval(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	lock := &meta.(*client).alertingMutex
+	client := meta.(*client)
+
+	key := alertRuleGroupKey{
+		folderUID: data.Get("folder_uid").(string),
+		name:      data.Get("rule_group").(string),
+	}
+
+	// The group is saved with its current rules, so that only the interval changes
+	lock.Lock()
+	defer lock.Unlock()
+	group, err := client.alertRuleGroup(key.folderUID, key.name)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "status: 404") {
+			return diag.Errorf("rule group %q does not exist in folder %s. It is created with its first rule, make this resource depend on the rules of the group", key.name, key.folderUID)
+		}
+		return diag.FromErr(err)
+	}
+	group.Interval = int64(data.Get("interval_seconds").(int))
+	// Saving the group sets the provenance of its rules, so the current one is kept
+	disableProvenance := len(group.Rules) > 0 && group.Rules[0].Provenance == ""
+	if err := client.setAlertRuleGroup(&group, disableProvenance); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting the interval of rule group %q: %w", key.name, err))
+	}
+
+	data.SetId(packGroupID(key))
+	return readRuleGroupInterval(ctx, data, meta)
+}
+
+func deleteRuleGroupInterval(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Groups always have an interval, so there is nothing to delete
+	return nil
+}
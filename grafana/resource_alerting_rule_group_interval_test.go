@@ -0,0 +1,49 @@
+package grafana
+
+import (
+	"testing"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccRuleGroupInterval_basic(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=9.1.0")
+
+	var group gapi.RuleGroup
+	var rule gapi.AlertRule
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccAlertRuleCheckDestroy(&rule),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_rule_group_interval/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccAlertRuleCheckExists("grafana_alert_rule.team_a", &rule),
+					testRuleGroupCheckExists("grafana_rule_group_interval.shared", &group),
+					resource.TestCheckResourceAttr("grafana_rule_group_interval.shared", "rule_group", "Shared Rule Group"),
+					resource.TestCheckResourceAttr("grafana_rule_group_interval.shared", "interval_seconds", "120"),
+					testAccAlertRuleGroupCheckRules(&rule, "Team A Rule"),
+				),
+			},
+			{
+				ResourceName:      "grafana_rule_group_interval.shared",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Changing the interval keeps the rules of the group
+				Config: testAccExampleWithReplace(t, "resources/grafana_rule_group_interval/resource.tf", map[string]string{
+					"interval_seconds = 120": "interval_seconds = 300",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testRuleGroupCheckExists("grafana_rule_group_interval.shared", &group),
+					resource.TestCheckResourceAttr("grafana_rule_group_interval.shared", "interval_seconds", "300"),
+					testAccAlertRuleGroupCheckRules(&rule, "Team A Rule"),
+				),
+			},
+		},
+	})
+}
@@ -2,10 +2,12 @@ package grafana
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -163,6 +165,200 @@ func TestAccAlertRule_compound(t *testing.T) {
 	})
 }
 
+func TestAccAlertRule_expressions(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=9.1.0")
+
+	var group gapi.RuleGroup
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		// Implicitly tests deletion.
+		CheckDestroy: testAlertRuleCheckDestroy(&group),
+		Steps: []resource.TestStep{
+			// Test creation.
+			{
+				Config: testAccExample(t, "resources/grafana_rule_group/_acc_expressions.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testRuleGroupCheckExists("grafana_rule_group.my_expressions_group", &group),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_expressions_group", "rule.#", "2"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_expressions_group", "rule.0.data.1.datasource_uid", "-100"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_expressions_group", "rule.0.data.1.model", ""),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_expressions_group", "rule.0.data.1.resample.0.window", "10s"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_expressions_group", "rule.0.data.2.reduce.0.mode", "dropNN"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_expressions_group", "rule.0.data.3.threshold.0.evaluator.0.params.0", "3"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_expressions_group", "rule.1.data.1.math.0.expression", "$A * 100"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_expressions_group", "rule.1.data.2.classic_condition.0.condition.#", "2"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_expressions_group", "rule.1.data.2.classic_condition.0.condition.1.operator", "or"),
+					func(s *terraform.State) error {
+						model, _ := group.Rules[0].Data[2].Model.(map[string]interface{})
+						if model["type"] != "reduce" || model["expression"] != "B" {
+							return fmt.Errorf("unexpected model for the reduce expression: %v", model)
+						}
+						return nil
+					},
+				),
+			},
+			// Test update.
+			{
+				Config: testAccExampleWithReplace(t, "resources/grafana_rule_group/_acc_expressions.tf", map[string]string{
+					"params = [3]": "params = [5]",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testRuleGroupCheckExists("grafana_rule_group.my_expressions_group", &group),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_expressions_group", "rule.0.data.3.threshold.0.evaluator.0.params.0", "5"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAlertRule_recordingRule(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=11.4.0")
+
+	var group gapi.RuleGroup
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		// Implicitly tests deletion.
+		CheckDestroy: testAlertRuleCheckDestroy(&group),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_rule_group/_acc_recording_rule.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testRuleGroupCheckExists("grafana_rule_group.my_recording_group", &group),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_recording_group", "rule.#", "2"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_recording_group", "rule.0.record.0.metric", "my_recorded_metric"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_recording_group", "rule.0.record.0.from", "B"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_recording_group", "rule.0.condition", ""),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_recording_group", "rule.1.record.#", "0"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_recording_group", "rule.1.condition", "A"),
+				),
+			},
+			{
+				Config: testAccExampleWithReplace(t, "resources/grafana_rule_group/_acc_recording_rule.tf", map[string]string{
+					"my_recorded_metric": "my_other_metric",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testRuleGroupCheckExists("grafana_rule_group.my_recording_group", &group),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_recording_group", "rule.0.record.0.metric", "my_other_metric"),
+				),
+			},
+			{
+				Config: testAccExampleWithReplace(t, "resources/grafana_rule_group/_acc_recording_rule.tf", map[string]string{
+					`name = "My Recording Rule"`: `name = "My Recording Rule"
+        no_data_state = "OK"`,
+				}),
+				ExpectError: regexp.MustCompile("`no_data_state` can't be set on recording rules"),
+			},
+		},
+	})
+}
+
+func TestValidateAlertRuleKind(t *testing.T) {
+	IsUnitTest(t)
+
+	rule := func(condition string, record bool) map[string]interface{} {
+		r := map[string]interface{}{
+			"name":      "rule",
+			"condition": condition,
+			"record":    []interface{}{},
+			"data": []interface{}{
+				map[string]interface{}{"ref_id": "A"},
+				map[string]interface{}{"ref_id": "B"},
+			},
+		}
+		if record {
+			r["record"] = []interface{}{map[string]interface{}{"metric": "metric", "from": "B"}}
+		}
+		return r
+	}
+	config := func(attrs map[string]cty.Value) cty.Value {
+		values := map[string]cty.Value{
+			"condition":             cty.NullVal(cty.String),
+			"for":                   cty.NullVal(cty.String),
+			"no_data_state":         cty.NullVal(cty.String),
+			"exec_err_state":        cty.NullVal(cty.String),
+			"notification_settings": cty.ListValEmpty(cty.EmptyObject),
+		}
+		for k, v := range attrs {
+			values[k] = v
+		}
+		return cty.ObjectVal(values)
+	}
+
+	cases := []struct {
+		name          string
+		rule          map[string]interface{}
+		config        cty.Value
+		expectedError string
+	}{
+		{
+			name:   "alert rule",
+			rule:   rule("B", false),
+			config: config(map[string]cty.Value{"condition": cty.StringVal("B")}),
+		},
+		{
+			name:          "alert rule without condition",
+			rule:          rule("", false),
+			config:        config(nil),
+			expectedError: "`condition` is required for alert rules",
+		},
+		{
+			name:   "alert rule with an unknown condition",
+			rule:   rule("", false),
+			config: config(map[string]cty.Value{"condition": cty.UnknownVal(cty.String)}),
+		},
+		{
+			name:   "recording rule",
+			rule:   rule("", true),
+			config: config(nil),
+		},
+		{
+			name:          "recording rule with a condition",
+			rule:          rule("B", true),
+			config:        config(map[string]cty.Value{"condition": cty.StringVal("B")}),
+			expectedError: "`condition` can't be set on recording rules",
+		},
+		{
+			name:          "recording rule with no_data_state",
+			rule:          rule("", true),
+			config:        config(map[string]cty.Value{"no_data_state": cty.StringVal("NoData")}),
+			expectedError: "`no_data_state` can't be set on recording rules",
+		},
+		{
+			name:          "recording rule with notification settings",
+			rule:          rule("", true),
+			config:        config(map[string]cty.Value{"notification_settings": cty.ListVal([]cty.Value{cty.EmptyObjectVal})}),
+			expectedError: "`notification_settings` can't be set on recording rules",
+		},
+		{
+			name: "recording rule from an unknown stage",
+			rule: func() map[string]interface{} {
+				r := rule("", true)
+				r["record"] = []interface{}{map[string]interface{}{"metric": "metric", "from": "C"}}
+				return r
+			}(),
+			config:        config(nil),
+			expectedError: "it must be the `ref_id` of one of the `data` stages: A, B",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateAlertRuleKind(tc.rule, tc.config)
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Errorf("expected error containing %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
 func testRuleGroupCheckExists(rname string, g *gapi.RuleGroup) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		resource, ok := s.RootModule().Resources[rname]
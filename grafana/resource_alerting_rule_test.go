@@ -0,0 +1,219 @@
+package grafana
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAlertRuleStandalone_basic(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=9.1.0")
+
+	var rule gapi.AlertRule
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccAlertRuleCheckDestroy(&rule),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_alert_rule/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccAlertRuleCheckExists("grafana_alert_rule.high_cpu", &rule),
+					resource.TestCheckResourceAttr("grafana_alert_rule.high_cpu", "name", "High CPU"),
+					resource.TestCheckResourceAttr("grafana_alert_rule.high_cpu", "rule_group", "Shared Rule Group"),
+					resource.TestCheckResourceAttrPair("grafana_alert_rule.high_cpu", "folder_uid", "grafana_folder.rule_folder", "uid"),
+					resource.TestCheckResourceAttr("grafana_alert_rule.high_cpu", "org_id", "1"),
+					resource.TestCheckResourceAttr("grafana_alert_rule.high_cpu", "for", "2m"),
+					resource.TestCheckResourceAttr("grafana_alert_rule.high_cpu", "data.#", "2"),
+					resource.TestCheckResourceAttr("grafana_alert_rule.high_cpu", "labels.team", "infra"),
+				),
+			},
+			{
+				ResourceName:      "grafana_alert_rule.high_cpu",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// A rule added to the group next to the first one doesn't change it
+				Config: testAccExample(t, "resources/grafana_alert_rule/resource.tf") + testAccAlertRuleSecondRule,
+				Check: resource.ComposeTestCheckFunc(
+					testAccAlertRuleCheckExists("grafana_alert_rule.high_cpu", &rule),
+					testAccAlertRuleCheckExists("grafana_alert_rule.second", &gapi.AlertRule{}),
+					testAccAlertRuleGroupCheckRules(&rule, "High CPU", "Second Rule"),
+				),
+			},
+			{
+				Config: testAccExampleWithReplace(t, "resources/grafana_alert_rule/resource.tf", map[string]string{
+					`"High CPU"`: `"Very High CPU"`,
+					"[80]":       "[95]",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccAlertRuleCheckExists("grafana_alert_rule.high_cpu", &rule),
+					resource.TestCheckResourceAttr("grafana_alert_rule.high_cpu", "name", "Very High CPU"),
+					testAccAlertRuleGroupCheckRules(&rule, "Very High CPU"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAlertRuleStandalone_notificationSettings(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=10.4.0")
+
+	var rule gapi.AlertRule
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccAlertRuleCheckDestroy(&rule),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAlertRuleNotificationSettings,
+				Check: resource.ComposeTestCheckFunc(
+					testAccAlertRuleCheckExists("grafana_alert_rule.routed", &rule),
+					resource.TestCheckResourceAttr("grafana_alert_rule.routed", "is_paused", "true"),
+					resource.TestCheckResourceAttr("grafana_alert_rule.routed", "disable_provenance", "true"),
+					resource.TestCheckResourceAttr("grafana_alert_rule.routed", "notification_settings.0.contact_point", "Routed Contact Point"),
+					resource.TestCheckResourceAttr("grafana_alert_rule.routed", "notification_settings.0.group_by.#", "2"),
+					resource.TestCheckResourceAttr("grafana_alert_rule.routed", "notification_settings.0.repeat_interval", "4h"),
+					func(s *terraform.State) error {
+						got, err := testAccProvider.Meta().(*client).alertRule(rule.UID)
+						if err != nil {
+							return err
+						}
+						if !got.IsPaused || got.Provenance != "" || got.NotificationSettings == nil || got.NotificationSettings.Receiver != "Routed Contact Point" {
+							return fmt.Errorf("unexpected rule: paused %t, provenance %q, notification settings %+v", got.IsPaused, got.Provenance, got.NotificationSettings)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "grafana_alert_rule.routed",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: strings.Replace(testAccAlertRuleNotificationSettings, "is_paused          = true", "is_paused          = false", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccAlertRuleCheckExists("grafana_alert_rule.routed", &rule),
+					resource.TestCheckResourceAttr("grafana_alert_rule.routed", "is_paused", "false"),
+				),
+			},
+		},
+	})
+}
+
+const testAccAlertRuleNotificationSettings = `
+resource "grafana_folder" "rule_folder" {
+  title = "Routed Rule Folder"
+}
+
+resource "grafana_contact_point" "routed" {
+  name = "Routed Contact Point"
+  email {
+    addresses = ["oncall@example.com"]
+  }
+}
+
+resource "grafana_alert_rule" "routed" {
+  name               = "Routed Rule"
+  folder_uid         = grafana_folder.rule_folder.uid
+  rule_group         = "Routed Rule Group"
+  condition          = "A"
+  is_paused          = true
+  disable_provenance = true
+  notification_settings {
+    contact_point   = grafana_contact_point.routed.name
+    group_by        = ["alertname", "team"]
+    repeat_interval = "4h"
+  }
+  data {
+    ref_id = "A"
+    math {
+      expression = "1 > 0"
+    }
+  }
+}
+`
+
+const testAccAlertRuleSecondRule = `
+resource "grafana_alert_rule" "second" {
+  name       = "Second Rule"
+  folder_uid = grafana_folder.rule_folder.uid
+  rule_group = grafana_alert_rule.high_cpu.rule_group
+  condition  = "A"
+  data {
+    ref_id = "A"
+    relative_time_range {
+      from = 0
+      to   = 0
+    }
+    datasource_uid = "-100"
+    model = jsonencode({
+      datasource = { type = "__expr__", uid = "-100" }
+      expression = "1 > 0"
+      refId      = "A"
+      type       = "math"
+    })
+  }
+}
+`
+
+func testAccAlertRuleCheckExists(rn string, rule *gapi.AlertRule) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		got, err := client.AlertRule(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting alert rule: %s", err)
+		}
+		*rule = got
+		return nil
+	}
+}
+
+// testAccAlertRuleGroupCheckRules checks the titles of the rules in the group of the rule.
+func testAccAlertRuleGroupCheckRules(rule *gapi.AlertRule, titles ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*client).gapi
+		group, err := client.AlertRuleGroup(rule.FolderUID, rule.RuleGroup)
+		if err != nil {
+			return fmt.Errorf("error getting rule group: %s", err)
+		}
+		var got []string
+		for _, r := range group.Rules {
+			got = append(got, r.Title)
+		}
+		if strings.Join(got, ",") != strings.Join(titles, ",") {
+			return fmt.Errorf("expected rules %v in group %s, got %v", titles, rule.RuleGroup, got)
+		}
+		return nil
+	}
+}
+
+func testAccAlertRuleCheckDestroy(rule *gapi.AlertRule) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*client).gapi
+		_, err := client.AlertRule(rule.UID)
+		if err == nil {
+			return fmt.Errorf("alert rule %s still exists", rule.UID)
+		}
+		if !strings.HasPrefix(err.Error(), "status: 404") {
+			return err
+		}
+		return nil
+	}
+}
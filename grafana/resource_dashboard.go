@@ -30,6 +30,7 @@ Manages Grafana dashboards.
 		ReadContext:   ReadDashboard,
 		UpdateContext: UpdateDashboard,
 		DeleteContext: DeleteDashboard,
+		CustomizeDiff: customizeDashboardDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -75,11 +76,33 @@ Manages Grafana dashboards.
 				},
 			},
 			"config_json": {
-				Type:         schema.TypeString,
-				Required:     true,
-				StateFunc:    normalizeDashboardConfigJSON,
-				ValidateFunc: validateDashboardConfigJSON,
-				Description:  "The complete dashboard model JSON.",
+				Type:             schema.TypeString,
+				Required:         true,
+				StateFunc:        normalizeDashboardConfigJSON,
+				ValidateFunc:     validateDashboardConfigJSON,
+				DiffSuppressFunc: suppressJSONModelDiff(normalizeDashboardConfigJSON, prepareDashboardModel),
+				Description: "The complete dashboard model JSON. " +
+					"It is checked for common mistakes at plan time: missing title, uid longer than 40 characters, duplicate panel IDs, overlapping panels, unknown variables in queries and unknown data sources. " +
+					"These are reported as warnings, unless `strict_validation` is set.",
+			},
+			"ignore_paths": ignorePathsSchema("dashboard model"),
+			"strict_validation": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Set to true to fail the plan when `config_json` has problems, instead of reporting them as warnings. " +
+					"Data sources are looked up in Grafana and among the data sources planned in the same run. The ones created in the same apply must be referenced through their `uid` attribute, " +
+					"so that they're planned before the dashboard.",
+			},
+			"apply_schema_migrations": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Set to true to apply the schema migrations of Grafana to `config_json` and to the dashboard in Grafana before comparing them. " +
+					"This prevents diffs when dashboards exported from older Grafana versions are migrated by Grafana on save. " +
+					"Only the migrations to the schema versions " + dashboardSchemaMigrationVersions() + " are supported. " +
+					"`schemaVersion` itself is not compared in this mode, unless a migration that the dashboard needs is not supported, in which case it's left untouched. " +
+					"The dashboard is still sent to Grafana as configured.",
 			},
 			"overwrite": {
 				Type:        schema.TypeBool,
@@ -187,7 +210,7 @@ func CreateDashboard(ctx context.Context, d *schema.ResourceData, meta interface
 	}
 	d.SetId(resp.UID)
 	d.Set("uid", resp.UID)
-	return ReadDashboard(ctx, d, meta)
+	return append(dashboardDatasourceWarnings(d, meta), ReadDashboard(ctx, d, meta)...)
 }
 
 func ReadDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -248,6 +271,7 @@ func ReadDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}
 			delete(remoteDashJSON, "uid")
 		}
 	}
+	prepareDashboardModel(d, remoteDashJSON)
 	configJSON = normalizeDashboardConfigJSON(remoteDashJSON)
 	d.Set("config_json", configJSON)
 
@@ -260,6 +284,14 @@ func UpdateDashboard(ctx context.Context, d *schema.ResourceData, meta interface
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if ignorePaths := listToStringSlice(d.Get("ignore_paths").([]interface{})); len(ignorePaths) > 0 {
+		// Keep the values of the ignored paths that are in Grafana
+		remote, err := client.DashboardByUID(d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		copyJSONPaths(remote.Model, dashboard.Model, ignorePaths)
+	}
 	dashboard.Model["id"] = d.Get("dashboard_id").(int)
 	dashboard.Overwrite = true
 	resp, err := client.NewDashboard(dashboard)
@@ -268,7 +300,7 @@ func UpdateDashboard(ctx context.Context, d *schema.ResourceData, meta interface
 	}
 	d.SetId(resp.UID)
 	d.Set("uid", resp.UID)
-	return ReadDashboard(ctx, d, meta)
+	return append(dashboardDatasourceWarnings(d, meta), ReadDashboard(ctx, d, meta)...)
 }
 
 func DeleteDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -320,7 +352,8 @@ func unmarshalDashboardConfigJSON(configJSON string) (map[string]interface{}, er
 }
 
 // validateDashboardConfigJSON is the ValidateFunc for `config_json`. It
-// ensures its value is valid JSON.
+// ensures its value is valid JSON, and returns the problems found by
+// lintDashboardModel as warnings.
 func validateDashboardConfigJSON(config interface{}, k string) ([]string, []error) {
 	configJSON := config.(string)
 	configMap := map[string]interface{}{}
@@ -328,17 +361,68 @@ func validateDashboardConfigJSON(config interface{}, k string) ([]string, []erro
 	if err != nil {
 		return nil, []error{err}
 	}
-	return nil, nil
+	var warnings []string
+	for _, problem := range lintDashboardModel(configMap) {
+		warnings = append(warnings, fmt.Sprintf("%s: %s", k, problem))
+	}
+	return warnings, nil
+}
+
+// customizeDashboardDiff fails the plan if `strict_validation` is set and the
+// dashboard has problems. The checks that don't need the Grafana API are
+// reported as warnings by validateDashboardConfigJSON otherwise.
+func customizeDashboardDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("strict_validation").(bool) || (d.Id() != "" && !d.HasChange("config_json") && !d.HasChange("strict_validation")) {
+		return nil
+	}
+
+	// The value in the diff went through the StateFunc, which removes panel IDs, so the configured one is used
+	configured := d.GetRawConfig().GetAttr("config_json")
+	if !configured.IsKnown() || configured.IsNull() {
+		return nil
+	}
+	dashboardJSON, err := unmarshalDashboardConfigJSON(configured.AsString())
+	if err != nil {
+		return nil
+	}
+
+	problems := lintDashboardModel(dashboardJSON)
+	datasourceProblems, err := lintDashboardDatasources(meta.(*client), dashboardJSON)
+	if err != nil {
+		return fmt.Errorf("error checking the data sources of the dashboard: %w", err)
+	}
+	problems = append(problems, datasourceProblems...)
+
+	if len(problems) > 0 {
+		return fmt.Errorf("the dashboard has %d problem(s):\n  - %s", len(problems), strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// dashboardDatasourceWarnings returns the data sources of the dashboard that don't exist in Grafana as warnings.
+// This check can't be done at plan time without `strict_validation`, since CustomizeDiff can't return warnings.
+func dashboardDatasourceWarnings(d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.Get("strict_validation").(bool) {
+		return nil
+	}
+	dashboardJSON, err := unmarshalDashboardConfigJSON(d.Get("config_json").(string))
+	if err != nil {
+		return nil
+	}
+	problems, err := lintDashboardDatasources(meta.(*client), dashboardJSON)
+	if err != nil {
+		return diag.Diagnostics{{Severity: diag.Warning, Summary: "Could not check the data sources of the dashboard", Detail: err.Error()}}
+	}
+	var diags diag.Diagnostics
+	for _, problem := range problems {
+		diags = append(diags, diag.Diagnostic{Severity: diag.Warning, Summary: "Unknown data source in dashboard", Detail: problem})
+	}
+	return diags
 }
 
 // normalizeDashboardConfigJSON is the StateFunc for the `config_json` field.
-//
-// It removes the following fields:
-//
-//   - `id`:      an auto-incrementing ID Grafana assigns to dashboards upon
-//     creation. We cannot know this before creation and therefore it cannot
-//     be managed in code.
-//   - `version`: is incremented by Grafana each time a dashboard changes.
+// The dashboard model is normalized with normalizeDashboardModel, and only its
+// sha256 sum is returned if `store_dashboard_sha256` is set on the provider.
 func normalizeDashboardConfigJSON(config interface{}) string {
 	var dashboardJSON map[string]interface{}
 	switch c := config.(type) {
@@ -352,33 +436,71 @@ func normalizeDashboardConfigJSON(config interface{}) string {
 		}
 	}
 
+	if storeDashboardSHA256 {
+		return dashboardModelHash(dashboardJSON)
+	}
+
+	normalizeDashboardModel(dashboardJSON)
+	j, _ := json.Marshal(dashboardJSON)
+	return string(j)
+}
+
+// dashboardModelHash returns the SHA256 hash of the normalized dashboard model.
+func dashboardModelHash(dashboardJSON map[string]interface{}) string {
+	normalizeDashboardModel(dashboardJSON)
+	j, _ := json.Marshal(dashboardJSON)
+	configHash := sha256.Sum256(j)
+	return fmt.Sprintf("%x", configHash[:])
+}
+
+// normalizeDashboardModel removes the following fields from a dashboard model:
+//
+//   - `id`:      an auto-incrementing ID Grafana assigns to dashboards upon
+//     creation. We cannot know this before creation and therefore it cannot
+//     be managed in code.
+//   - `version`: is incremented by Grafana each time a dashboard changes.
+//
+// Panels are normalized as well, including the ones nested in collapsed rows.
+func normalizeDashboardModel(dashboardJSON map[string]interface{}) {
 	delete(dashboardJSON, "id")
 	delete(dashboardJSON, "version")
 
-	// similarly to uid removal above, remove any attributes panels[].libraryPanel.*
-	// from the dashboard JSON other than "name" or "uid".
-	// Grafana will populate all other libraryPanel attributes, so delete them to avoid diff.
-	panels, hasPanels := dashboardJSON["panels"]
-	if hasPanels {
-		for _, panel := range panels.([]interface{}) {
-			panelMap := panel.(map[string]interface{})
-			delete(panelMap, "id")
-			if libraryPanel, ok := panelMap["libraryPanel"].(map[string]interface{}); ok {
-				for k := range libraryPanel {
-					if k != "name" && k != "uid" {
-						delete(libraryPanel, k)
-					}
+	normalizeDashboardPanels(dashboardJSON["panels"])
+}
+
+func normalizeDashboardPanels(panels interface{}) {
+	panelList, ok := panels.([]interface{})
+	if !ok {
+		return
+	}
+	for _, panel := range panelList {
+		panelMap, ok := panel.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		delete(panelMap, "id")
+
+		// similarly to uid removal above, remove any attributes panels[].libraryPanel.*
+		// from the dashboard JSON other than "name" or "uid".
+		// Grafana will populate all other libraryPanel attributes, so delete them to avoid diff.
+		if libraryPanel, ok := panelMap["libraryPanel"].(map[string]interface{}); ok {
+			for k := range libraryPanel {
+				if k != "name" && k != "uid" {
+					delete(libraryPanel, k)
 				}
 			}
 		}
-	}
 
-	j, _ := json.Marshal(dashboardJSON)
+		// Collapsed rows hold their panels
+		normalizeDashboardPanels(panelMap["panels"])
+	}
+}
 
-	if storeDashboardSHA256 {
-		configHash := sha256.Sum256(j)
-		return fmt.Sprintf("%x", configHash[:])
-	} else {
-		return string(j)
+// prepareDashboardModel prepares a dashboard model for comparison, according to the
+// `apply_schema_migrations` and `ignore_paths` attributes.
+func prepareDashboardModel(d *schema.ResourceData, dashboardJSON map[string]interface{}) {
+	if d.Get("apply_schema_migrations").(bool) && migrateDashboardModel(dashboardJSON) {
+		delete(dashboardJSON, "schemaVersion")
 	}
+	removeIgnoredJSONPaths(d, dashboardJSON)
 }
@@ -0,0 +1,240 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceDashboardGnet() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Imports a community dashboard from [grafana.com](https://grafana.com/grafana/dashboards/).
+
+The dashboard is downloaded from the Grafana.com API (the ` + "`cloud_api_url`" + ` provider attribute), its inputs (` + "`${DS_PROMETHEUS}`" + ` style placeholders) are replaced by the values of ` + "`inputs`" + `, and it is imported in Grafana.
+Changing ` + "`gnet_revision`" + ` or ` + "`inputs`" + ` imports the dashboard again, overwriting the changes made to it in Grafana.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/manage-dashboards/#import-a-dashboard)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/dashboard/)
+`,
+
+		CreateContext: CreateDashboardGnet,
+		ReadContext:   ReadDashboardGnet,
+		UpdateContext: UpdateDashboardGnet,
+		DeleteContext: DeleteDashboardGnet,
+
+		Schema: map[string]*schema.Schema{
+			"gnet_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The ID of the dashboard on grafana.com.",
+			},
+			"gnet_revision": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The revision of the dashboard on grafana.com. Bumping it upgrades the dashboard.",
+			},
+			"inputs": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "The values of the inputs of the dashboard, by name (`DS_PROMETHEUS` for the `${DS_PROMETHEUS}` placeholder). " +
+					"Data source inputs take a data source UID. All data source inputs must be set, constant inputs default to the value defined in the dashboard.",
+			},
+			"uid": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Description: "The unique identifier of the dashboard in Grafana. " +
+					"Defaults to the uid of the dashboard on grafana.com, or to a generated one if it has none.",
+			},
+			"folder": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The id of the folder to import the dashboard in. This attribute is a string to reflect the type of the folder's id.",
+				ValidateFunc: validation.StringMatch(idRegexp, "must be a valid folder id"),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return old == "0" && new == "" || old == "" && new == "0"
+				},
+			},
+			"overwrite": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Set to true to overwrite an existing dashboard with the same uid or the same title in the folder.",
+			},
+			"title": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The title of the dashboard.",
+			},
+			"dashboard_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The numeric ID of the dashboard computed by Grafana.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The full URL of the dashboard.",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The version of the dashboard in Grafana.",
+			},
+		},
+	}
+}
+
+func CreateDashboardGnet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	uid, err := importGnetDashboard(d, meta.(*client), d.Get("overwrite").(bool))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(uid)
+	return ReadDashboardGnet(ctx, d, meta)
+}
+
+func ReadDashboardGnet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	gapiURL := meta.(*client).gapiURL
+	client := meta.(*client).gapi
+
+	dashboard, err := client.DashboardByUID(d.Id())
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "status: 404") {
+			diags := diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Dashboard %q is in state, but no longer exists in grafana", d.Id()),
+				Detail:   fmt.Sprintf("%q will be imported again when you apply", d.Id()),
+			}}
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("uid", d.Id())
+	d.Set("title", dashboard.Model["title"])
+	d.Set("dashboard_id", int64(dashboard.Model["id"].(float64)))
+	d.Set("version", int64(dashboard.Model["version"].(float64)))
+	d.Set("url", strings.TrimRight(gapiURL, "/")+dashboard.Meta.URL)
+	if dashboard.FolderID > 0 {
+		d.Set("folder", strconv.FormatInt(dashboard.FolderID, 10))
+	} else {
+		d.Set("folder", "")
+	}
+
+	return nil
+}
+
+func UpdateDashboardGnet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// The dashboard is managed by this resource, so it is always overwritten
+	if _, err := importGnetDashboard(d, meta.(*client), true); err != nil {
+		return diag.FromErr(err)
+	}
+	return ReadDashboardGnet(ctx, d, meta)
+}
+
+func DeleteDashboardGnet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client).gapi
+	err := client.DeleteDashboardByUID(d.Id())
+	if err != nil && !strings.HasPrefix(err.Error(), "status: 404") {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// importGnetDashboard downloads the configured dashboard revision and imports it. It returns the uid of the dashboard.
+func importGnetDashboard(d *schema.ResourceData, c *client, overwrite bool) (string, error) {
+	gnetID := int64(d.Get("gnet_id").(int))
+	revision := int64(d.Get("gnet_revision").(int))
+	dashboard, err := c.gnetDashboard(gnetID, revision)
+	if err != nil {
+		return "", fmt.Errorf("error downloading dashboard %d revision %d from grafana.com: %w", gnetID, revision, err)
+	}
+
+	inputs, err := gnetDashboardImportInputs(dashboard, d.Get("inputs").(map[string]interface{}))
+	if err != nil {
+		return "", err
+	}
+
+	if uid := d.Get("uid").(string); uid != "" {
+		dashboard["uid"] = uid
+	}
+	// Grafana links imported dashboards to grafana.com through the gnetId attribute
+	dashboard["gnetId"] = gnetID
+	delete(dashboard, "id")
+
+	folderID, _ := strconv.ParseInt(d.Get("folder").(string), 10, 64)
+	response, err := c.importDashboard(&dashboardImportRequest{
+		Dashboard: dashboard,
+		Overwrite: overwrite,
+		Inputs:    inputs,
+		FolderID:  folderID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error importing dashboard %d revision %d: %w", gnetID, revision, err)
+	}
+	return response.UID, nil
+}
+
+// gnetDashboardImportInputs returns the values of the `__inputs` of a dashboard.
+// Data source inputs must be configured, constants default to the value of the input.
+func gnetDashboardImportInputs(dashboard map[string]interface{}, values map[string]interface{}) ([]dashboardImportInput, error) {
+	var inputs []dashboardImportInput
+	known := map[string]bool{}
+	var missing []string
+
+	dashboardInputs, _ := dashboard["__inputs"].([]interface{})
+	for _, i := range dashboardInputs {
+		input, ok := i.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := input["name"].(string)
+		inputType, _ := input["type"].(string)
+		pluginID, _ := input["pluginId"].(string)
+		known[name] = true
+
+		value, ok := values[name].(string)
+		if !ok {
+			if inputType == "datasource" {
+				missing = append(missing, fmt.Sprintf("%s (%s data source)", name, pluginID))
+				continue
+			}
+			value, _ = input["value"].(string)
+		}
+		inputs = append(inputs, dashboardImportInput{
+			Name:     name,
+			Type:     inputType,
+			PluginID: pluginID,
+			Value:    value,
+		})
+	}
+
+	var unknown []string
+	for name := range values {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("the following dashboard inputs must be set in `inputs`: %s", strings.Join(missing, ", "))
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("the dashboard has no inputs named %s", strings.Join(unknown, ", "))
+	}
+	return inputs, nil
+}
@@ -0,0 +1,83 @@
+package grafana
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDashboardGnet_basic(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+
+	var dashboard gapi.Dashboard
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccDashboardCheckDestroy(&dashboard),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_dashboard_gnet/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard_gnet.node_exporter", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard_gnet.node_exporter", "id", "node-exporter-full"),
+					resource.TestCheckResourceAttr("grafana_dashboard_gnet.node_exporter", "uid", "node-exporter-full"),
+					resource.TestCheckResourceAttr("grafana_dashboard_gnet.node_exporter", "title", "Node Exporter Full"),
+					resource.TestCheckResourceAttr("grafana_dashboard_gnet.node_exporter", "version", "1"),
+					resource.TestMatchResourceAttr("grafana_dashboard_gnet.node_exporter", "dashboard_id", idRegexp),
+				),
+			},
+			{
+				// Upgrade the dashboard
+				Config: strings.Replace(testAccExample(t, "resources/grafana_dashboard_gnet/resource.tf"), "gnet_revision = 30", "gnet_revision = 31", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard_gnet.node_exporter", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard_gnet.node_exporter", "id", "node-exporter-full"),
+					resource.TestCheckResourceAttr("grafana_dashboard_gnet.node_exporter", "version", "2"),
+				),
+			},
+			{
+				Config:      strings.Replace(testAccExample(t, "resources/grafana_dashboard_gnet/resource.tf"), "DS_PROMETHEUS", "DS_PROM", 1),
+				ExpectError: regexp.MustCompile(`the following dashboard inputs must be set in .inputs.: DS_PROMETHEUS \(prometheus data source\)`),
+			},
+		},
+	})
+}
+
+func TestGnetDashboardImportInputs(t *testing.T) {
+	IsUnitTest(t)
+
+	dashboard := map[string]interface{}{
+		"__inputs": []interface{}{
+			map[string]interface{}{"name": "DS_PROMETHEUS", "type": "datasource", "pluginId": "prometheus"},
+			map[string]interface{}{"name": "VAR_JOB", "type": "constant", "value": "node"},
+			map[string]interface{}{"name": "VAR_ENV", "type": "constant", "value": "dev"},
+		},
+	}
+
+	inputs, err := gnetDashboardImportInputs(dashboard, map[string]interface{}{
+		"DS_PROMETHEUS": "prometheus-uid",
+		"VAR_ENV":       "prod",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []dashboardImportInput{
+		{Name: "DS_PROMETHEUS", Type: "datasource", PluginID: "prometheus", Value: "prometheus-uid"},
+		{Name: "VAR_JOB", Type: "constant", Value: "node"},
+		{Name: "VAR_ENV", Type: "constant", Value: "prod"},
+	}
+	if !reflect.DeepEqual(inputs, expected) {
+		t.Errorf("expected %+v, got %+v", expected, inputs)
+	}
+
+	if _, err := gnetDashboardImportInputs(dashboard, map[string]interface{}{}); err == nil || err.Error() != "the following dashboard inputs must be set in `inputs`: DS_PROMETHEUS (prometheus data source)" {
+		t.Errorf("unexpected error for a missing data source: %v", err)
+	}
+	if _, err := gnetDashboardImportInputs(dashboard, map[string]interface{}{"DS_PROMETHEUS": "uid", "DS_LOKI": "uid"}); err == nil || err.Error() != "the dashboard has no inputs named DS_LOKI" {
+		t.Errorf("unexpected error for an unknown input: %v", err)
+	}
+}
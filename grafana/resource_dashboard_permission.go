@@ -2,7 +2,9 @@ package grafana
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -19,6 +21,8 @@ func ResourceDashboardPermission() *schema.Resource {
 		Description: `
 * [Official documentation](https://grafana.com/docs/grafana/latest/permissions/dashboard_folder_permissions/)
 * [HTTP API](https://grafana.com/docs/grafana/latest/http_api/dashboard_permissions/)
+
+Permissions are managed through the access control API when Grafana has RBAC (Grafana 9+), and through the legacy dashboard permissions API otherwise.
 `,
 
 		CreateContext: UpdateDashboardPermissions,
@@ -28,10 +32,21 @@ func ResourceDashboardPermission() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"dashboard_id": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				ForceNew:    true,
-				Description: "ID of the dashboard to apply permissions to.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"dashboard_id", "dashboard_uid"},
+				Deprecated:   "Use `dashboard_uid` instead.",
+				Description:  "ID of the dashboard to apply permissions to.",
+			},
+			"dashboard_uid": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"dashboard_id", "dashboard_uid"},
+				Description:  "UID of the dashboard to apply permissions to.",
 			},
 			"permissions": {
 				Type:        schema.TypeSet,
@@ -57,6 +72,12 @@ func ResourceDashboardPermission() *schema.Resource {
 							Default:     0,
 							Description: "ID of the user to manage permissions for.",
 						},
+						"service_account_id": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     0,
+							Description: "ID of the service account to manage permissions for.",
+						},
 						"permission": {
 							Type:         schema.TypeString,
 							Required:     true,
@@ -67,72 +88,172 @@ func ResourceDashboardPermission() *schema.Resource {
 				},
 			},
 		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceDashboardPermissionV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceDashboardPermissionStateUpgradeV0,
+				Version: 0,
+			},
+		},
 	}
 }
 
-func UpdateDashboardPermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+// resourceDashboardPermissionV0 is the original schema for this resource.
+// It was keyed by the numeric dashboard ID, which changes whenever a dashboard is recreated.
+func resourceDashboardPermissionV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"dashboard_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"permissions": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"team_id": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  0,
+						},
+						"user_id": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  0,
+						},
+						"permission": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceDashboardPermissionStateUpgradeV0 migrates from version 0 of this resource's schema to version 1.
+//   - Use the dashboard UID as the resource ID instead of the dashboard ID.
+//   - `dashboard_uid` and `permissions.service_account_id` fields added to schema.
+func resourceDashboardPermissionStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if permissions, ok := rawState["permissions"].([]interface{}); ok {
+		for _, permission := range permissions {
+			if permission, ok := permission.(map[string]interface{}); ok {
+				permission["service_account_id"] = 0
+			}
+		}
+	}
+
 	client := meta.(*client).gapi
+	dashboardID := int64(rawState["dashboard_id"].(float64))
+	uid, err := dashboardUIDFromID(client, dashboardID)
+	if err != nil {
+		return nil, fmt.Errorf("error attempting to migrate state: %w", err)
+	}
+	if uid == "" {
+		// Dashboard does not exist. Let Terraform recreate the permissions.
+		return rawState, nil
+	}
+	rawState["id"] = uid
+	rawState["dashboard_uid"] = uid
+	return rawState, nil
+}
+
+func UpdateDashboardPermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
 
 	v, ok := d.GetOk("permissions")
 	if !ok {
 		return nil
 	}
-	permissionList := gapi.PermissionItems{}
-	for _, permission := range v.(*schema.Set).List() {
-		permission := permission.(map[string]interface{})
-		permissionItem := gapi.PermissionItem{}
-		if permission["role"].(string) != "" {
-			permissionItem.Role = permission["role"].(string)
-		}
-		if permission["team_id"].(int) != -1 {
-			permissionItem.TeamID = int64(permission["team_id"].(int))
+
+	dashboardUID := d.Get("dashboard_uid").(string)
+	if dashboardUID == "" {
+		dashboardID := int64(d.Get("dashboard_id").(int))
+		uid, err := dashboardUIDFromID(client.gapi, dashboardID)
+		if err != nil {
+			return diag.FromErr(err)
 		}
-		if permission["user_id"].(int) != -1 {
-			permissionItem.UserID = int64(permission["user_id"].(int))
+		if uid == "" {
+			return diag.Errorf("dashboard with ID %d not found", dashboardID)
 		}
-		permissionItem.Permission = mapPermissionStringToInt64(permission["permission"].(string))
-		permissionList.Items = append(permissionList.Items, &permissionItem)
+		dashboardUID = uid
 	}
 
-	dashboardID := int64(d.Get("dashboard_id").(int))
-
-	err := client.UpdateDashboardPermissions(dashboardID, &permissionList)
+	rbac, err := client.resourcePermissionsSupported("dashboards")
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if rbac {
+		if err := client.replaceResourcePermissions("dashboards", dashboardUID, permissionsToResourceAssignments(v.(*schema.Set).List())); err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		dashboard, err := client.gapi.DashboardByUID(dashboardUID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		dashboardID := int64(dashboard.Model["id"].(float64))
+		if err := client.gapi.UpdateDashboardPermissions(dashboardID, permissionsToLegacyItems(v.(*schema.Set).List())); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
-	d.SetId(strconv.FormatInt(dashboardID, 10))
+	d.SetId(dashboardUID)
 
 	return ReadDashboardPermissions(ctx, d, meta)
 }
 
 func ReadDashboardPermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gapi
+	client := meta.(*client)
 
-	dashboardID := int64(d.Get("dashboard_id").(int))
+	dashboardUID := d.Id()
 
-	dashboardPermissions, err := client.DashboardPermissions(dashboardID)
+	dashboard, err := client.gapi.DashboardByUID(dashboardUID)
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "status: 404") {
-			log.Printf("[WARN] removing dashboard permissions %d from state because it no longer exists in grafana", dashboardID)
+			log.Printf("[WARN] removing dashboard permissions %s from state because the dashboard no longer exists in grafana", dashboardUID)
 			d.SetId("")
 			return nil
 		}
 
 		return diag.FromErr(err)
 	}
+	dashboardID := int64(dashboard.Model["id"].(float64))
+	d.Set("dashboard_uid", dashboardUID)
+	d.Set("dashboard_id", dashboardID)
+
+	rbac, err := client.resourcePermissionsSupported("dashboards")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if rbac {
+		permissions, err := client.listResourcePermissions("dashboards", dashboardUID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("permissions", resourcePermissionsToState(permissions))
+		return nil
+	}
+
+	dashboardPermissions, err := client.gapi.DashboardPermissions(dashboardID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
+	serviceAccountIDs := configuredServiceAccountIDs(d)
 	permissionItems := make([]interface{}, len(dashboardPermissions))
 	count := 0
 	for _, permission := range dashboardPermissions {
 		if permission.DashboardID != -1 {
-			permissionItem := make(map[string]interface{})
-			permissionItem["role"] = permission.Role
-			permissionItem["team_id"] = permission.TeamID
-			permissionItem["user_id"] = permission.UserID
-			permissionItem["permission"] = mapPermissionInt64ToString(permission.Permission)
-
-			permissionItems[count] = permissionItem
+			permissionItems[count] = legacyPermissionToState(permission.Role, permission.TeamID, permission.UserID, permission.Permission, serviceAccountIDs)
 			count++
 		}
 	}
@@ -146,12 +267,19 @@ func DeleteDashboardPermissions(ctx context.Context, d *schema.ResourceData, met
 	// since permissions are tied to dashboards, we can't really delete the permissions.
 	// we will simply remove all permissions, leaving a dashboard that only an admin can access.
 	// if for some reason the parent dashboard doesn't exist, we'll just ignore the error
-	client := meta.(*client).gapi
+	client := meta.(*client)
 
-	dashboardID := int64(d.Get("dashboard_id").(int))
-	emptyPermissions := gapi.PermissionItems{}
+	dashboardUID := d.Id()
 
-	err := client.UpdateDashboardPermissions(dashboardID, &emptyPermissions)
+	rbac, err := client.resourcePermissionsSupported("dashboards")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if rbac {
+		err = client.replaceResourcePermissions("dashboards", dashboardUID, nil)
+	} else {
+		err = client.gapi.UpdateDashboardPermissions(int64(d.Get("dashboard_id").(int)), &gapi.PermissionItems{})
+	}
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "status: 404") {
 			d.SetId("")
@@ -162,3 +290,22 @@ func DeleteDashboardPermissions(ctx context.Context, d *schema.ResourceData, met
 
 	return nil
 }
+
+// dashboardUIDFromID finds the UID of the dashboard with the given ID. An empty UID is returned if the dashboard doesn't exist.
+func dashboardUIDFromID(client *gapi.Client, id int64) (string, error) {
+	query := url.Values{
+		"type":         {"dash-db"},
+		"dashboardIds": {strconv.FormatInt(id, 10)},
+	}
+	resp, err := client.FolderDashboardSearch(query)
+	if err != nil {
+		return "", fmt.Errorf("error while searching for dashboard with ID %d: %w", id, err)
+	}
+	switch {
+	case len(resp) > 1:
+		return "", fmt.Errorf("many dashboards returned by Grafana while searching for dashboard with ID %d", id)
+	case len(resp) == 0:
+		return "", nil
+	}
+	return resp[0].UID, nil
+}
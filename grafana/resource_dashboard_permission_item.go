@@ -0,0 +1,33 @@
+package grafana
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var dashboardPermissionItem = &permissionItemResource{
+	resourceType: "dashboards",
+	uidAttribute: "dashboard_uid",
+	permissions:  []string{"View", "Edit", "Admin"},
+}
+
+func ResourceDashboardPermissionItem() *schema.Resource {
+	return &schema.Resource{
+
+		Description: `
+Manages a single permission item for a dashboard. Conflicts with the [grafana_dashboard_permission](https://registry.terraform.io/providers/grafana/grafana/latest/docs/resources/dashboard_permission) resource which manages the entire set of permissions for a dashboard.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/roles-and-permissions/access-control/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/dashboard_permissions/)
+
+This resource requires Grafana 9.0.0 or later.
+`,
+
+		CreateContext: dashboardPermissionItem.create,
+		ReadContext:   dashboardPermissionItem.read,
+		UpdateContext: dashboardPermissionItem.update,
+		DeleteContext: dashboardPermissionItem.delete,
+		Importer:      dashboardPermissionItem.importer(),
+
+		Schema: dashboardPermissionItem.schema("The UID of the dashboard."),
+	}
+}
@@ -1,10 +1,15 @@
 package grafana
 
 import (
+	"context"
 	"fmt"
-	"strconv"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 
+	gapi "github.com/grafana/grafana-api-golang-client"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -35,6 +40,29 @@ func TestAccDashboardPermission_basic(t *testing.T) {
 	})
 }
 
+func TestAccDashboardPermission_uid(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=9.0.0")
+
+	dashboardID := int64(-1)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccDashboardPermissionCheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_dashboard_permission/resource.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccDashboardPermissionsCheckExists("grafana_dashboard_permission.collectionPermission", &dashboardID),
+					resource.TestCheckResourceAttrPair("grafana_dashboard_permission.collectionPermission", "dashboard_uid", "grafana_dashboard.metrics", "uid"),
+					resource.TestCheckResourceAttrPair("grafana_dashboard_permission.collectionPermission", "dashboard_id", "grafana_dashboard.metrics", "dashboard_id"),
+					resource.TestCheckResourceAttr("grafana_dashboard_permission.collectionPermission", "permissions.#", "4"),
+				),
+			},
+		},
+	})
+}
+
 func testAccDashboardPermissionsCheckExists(rn string, dashboardID *int64) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -48,10 +76,11 @@ func testAccDashboardPermissionsCheckExists(rn string, dashboardID *int64) resou
 
 		client := testAccProvider.Meta().(*client).gapi
 
-		gotDashboardID, err := strconv.ParseInt(rs.Primary.ID, 10, 64)
+		dashboard, err := client.DashboardByUID(rs.Primary.ID)
 		if err != nil {
-			return fmt.Errorf("dashboard id is malformed")
+			return fmt.Errorf("Error getting dashboard: %s", err)
 		}
+		gotDashboardID := int64(dashboard.Model["id"].(float64))
 
 		_, err = client.DashboardPermissions(gotDashboardID)
 		if err != nil {
@@ -152,3 +181,97 @@ resource "grafana_user" "testAdminUser" {
   password = "zyx987"
 }
 `
+
+func TestResourceDashboardPermissionStateUpgradeV0(t *testing.T) {
+	IsUnitTest(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/search" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("dashboardIds") == "1" {
+			fmt.Fprint(w, `[{"id": 1, "uid": "my-dashboard", "type": "dash-db"}]`)
+			return
+		}
+		fmt.Fprint(w, `[]`)
+	}))
+	defer server.Close()
+	gapiClient, err := gapi.New(server.URL, gapi.Config{Client: server.Client()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := &client{gapi: gapiClient}
+
+	rawState := func(dashboardID float64) map[string]interface{} {
+		return map[string]interface{}{
+			"id":           "1",
+			"dashboard_id": dashboardID,
+			"permissions":  []interface{}{map[string]interface{}{"user_id": 2.0, "permission": "View"}},
+		}
+	}
+
+	upgraded, err := resourceDashboardPermissionStateUpgradeV0(context.Background(), rawState(1), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"id":            "my-dashboard",
+		"dashboard_id":  1.0,
+		"dashboard_uid": "my-dashboard",
+		"permissions":   []interface{}{map[string]interface{}{"user_id": 2.0, "permission": "View", "service_account_id": 0}},
+	}
+	if !reflect.DeepEqual(upgraded, expected) {
+		t.Errorf("expected %v, got %v", expected, upgraded)
+	}
+
+	// The state of a deleted dashboard is kept, so that Terraform plans to recreate the permissions
+	upgraded, err = resourceDashboardPermissionStateUpgradeV0(context.Background(), rawState(3), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upgraded["id"] != "1" || upgraded["dashboard_uid"] != nil {
+		t.Errorf("expected the state of the deleted dashboard to keep its ID, got %v", upgraded)
+	}
+}
+
+func TestResourcePermissionsSupported(t *testing.T) {
+	IsUnitTest(t)
+
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/api/access-control/folders/description":
+			fmt.Fprint(w, `{"permissions": ["View", "Edit", "Admin"]}`)
+		case "/api/access-control/dashboards/description":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	c := &client{gapiURL: server.URL, gapiConfig: &gapi.Config{Client: server.Client()}}
+
+	for i := 0; i < 2; i++ {
+		if supported, err := c.resourcePermissionsSupported("folders"); err != nil || !supported {
+			t.Errorf("expected the access control API to support folders, got %v, %v", supported, err)
+		}
+		if supported, err := c.resourcePermissionsSupported("datasources"); err != nil || supported {
+			t.Errorf("expected the access control API not to support data sources, got %v, %v", supported, err)
+		}
+		if _, err := c.resourcePermissionsSupported("dashboards"); err == nil {
+			t.Errorf("expected an error when the access control API is forbidden")
+		}
+	}
+
+	// Only the results are cached, the errors are retried
+	expected := map[string]int{
+		"/api/access-control/folders/description":     1,
+		"/api/access-control/datasources/description": 1,
+		"/api/access-control/dashboards/description":  2,
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("expected the requests %v, got %v", expected, requests)
+	}
+}
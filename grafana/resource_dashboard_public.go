@@ -0,0 +1,186 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const publicDashboardIDSeparator = ":"
+
+var publicDashboardAccessTokenRegexp = regexp.MustCompile(`^[a-f0-9]{32}$`)
+
+func ResourceDashboardPublic() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages the public sharing of a dashboard. A dashboard can only have one public dashboard.
+
+**Note:** This resource is available only with Grafana 10.0+.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/dashboard-public/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/dashboard_public/)
+`,
+
+		CreateContext: CreatePublicDashboard,
+		ReadContext:   ReadPublicDashboard,
+		UpdateContext: UpdatePublicDashboard,
+		DeleteContext: DeletePublicDashboard,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"dashboard_uid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The UID of the dashboard to share.",
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The unique identifier of the public dashboard. It's automatically generated if not provided.",
+			},
+			"access_token": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(publicDashboardAccessTokenRegexp, "must be a 32 characters hexadecimal string"),
+				Description:  "The access token used in the public URL of the dashboard, a 32 characters hexadecimal string. It's automatically generated if not provided.",
+			},
+			"is_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the dashboard is publicly accessible. When disabled, the public URL is kept but doesn't give access to the dashboard.",
+			},
+			"time_selection_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether viewers can change the time range of the public dashboard.",
+			},
+			"annotations_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether annotations are displayed on the public dashboard.",
+			},
+			"share": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "public",
+				ValidateFunc: validation.StringInSlice([]string{"public", "email"}, false),
+				Description:  "Who can access the public dashboard. `public` for anyone with the URL, `email` for the invited email addresses (Grafana Cloud only).",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The public URL of the dashboard.",
+			},
+		},
+	}
+}
+
+func CreatePublicDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+	dashboardUID := d.Get("dashboard_uid").(string)
+
+	publicDashboard := makePublicDashboard(d)
+	publicDashboard.UID = d.Get("uid").(string)
+	publicDashboard.AccessToken = d.Get("access_token").(string)
+
+	resp, err := client.newPublicDashboard(dashboardUID, publicDashboard)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(dashboardUID + publicDashboardIDSeparator + resp.UID)
+
+	return ReadPublicDashboard(ctx, d, meta)
+}
+
+func ReadPublicDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	dashboardUID, uid, err := unpackPublicDashboardID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	publicDashboard, err := client.publicDashboard(dashboardUID)
+	if err != nil && !strings.HasPrefix(err.Error(), "status: 404") {
+		return diag.FromErr(err)
+	}
+	if err != nil || publicDashboard.UID != uid {
+		log.Printf("[WARN] removing public dashboard %s from state because it no longer exists in grafana", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("dashboard_uid", dashboardUID)
+	d.Set("uid", publicDashboard.UID)
+	d.Set("access_token", publicDashboard.AccessToken)
+	d.Set("is_enabled", publicDashboard.IsEnabled)
+	d.Set("time_selection_enabled", publicDashboard.TimeSelectionEnabled)
+	d.Set("annotations_enabled", publicDashboard.AnnotationsEnabled)
+	if publicDashboard.Share != "" {
+		d.Set("share", publicDashboard.Share)
+	}
+	d.Set("url", strings.TrimRight(client.gapiURL, "/")+"/public-dashboards/"+publicDashboard.AccessToken)
+
+	return nil
+}
+
+func UpdatePublicDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	dashboardUID, uid, err := unpackPublicDashboardID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := client.updatePublicDashboard(dashboardUID, uid, makePublicDashboard(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ReadPublicDashboard(ctx, d, meta)
+}
+
+func DeletePublicDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	dashboardUID, uid, err := unpackPublicDashboardID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := client.deletePublicDashboard(dashboardUID, uid); err != nil && !strings.HasPrefix(err.Error(), "status: 404") {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func makePublicDashboard(d *schema.ResourceData) *publicDashboard {
+	return &publicDashboard{
+		IsEnabled:            d.Get("is_enabled").(bool),
+		TimeSelectionEnabled: d.Get("time_selection_enabled").(bool),
+		AnnotationsEnabled:   d.Get("annotations_enabled").(bool),
+		Share:                d.Get("share").(string),
+	}
+}
+
+func unpackPublicDashboardID(id string) (string, string, error) {
+	parts := strings.Split(id, publicDashboardIDSeparator)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid public dashboard ID %q, expected `<dashboard_uid>:<uid>`", id)
+	}
+	return parts[0], parts[1], nil
+}
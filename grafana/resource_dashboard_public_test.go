@@ -0,0 +1,79 @@
+package grafana
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDashboardPublic_basic(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=10.0.0")
+
+	var dashboard gapi.Dashboard
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccDashboardCheckDestroy(&dashboard),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_dashboard_public/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.status", &dashboard),
+					testAccDashboardPublicCheckExists("grafana_dashboard_public.status", true),
+					resource.TestCheckResourceAttr("grafana_dashboard_public.status", "dashboard_uid", "public-status"),
+					resource.TestCheckResourceAttr("grafana_dashboard_public.status", "is_enabled", "true"),
+					resource.TestCheckResourceAttr("grafana_dashboard_public.status", "time_selection_enabled", "true"),
+					resource.TestCheckResourceAttr("grafana_dashboard_public.status", "annotations_enabled", "true"),
+					resource.TestCheckResourceAttr("grafana_dashboard_public.status", "share", "public"),
+					resource.TestMatchResourceAttr("grafana_dashboard_public.status", "access_token", publicDashboardAccessTokenRegexp),
+					resource.TestMatchResourceAttr("grafana_dashboard_public.status", "url", regexp.MustCompile("^"+regexp.QuoteMeta(strings.TrimRight(os.Getenv("GRAFANA_URL"), "/"))+"/public-dashboards/[a-f0-9]{32}$")),
+				),
+			},
+			{
+				Config: strings.Replace(testAccExample(t, "resources/grafana_dashboard_public/resource.tf"), "is_enabled             = true", "is_enabled             = false", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardPublicCheckExists("grafana_dashboard_public.status", false),
+					resource.TestCheckResourceAttr("grafana_dashboard_public.status", "is_enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      "grafana_dashboard_public.status",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccDashboardPublicCheckExists(rn string, enabled bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+		dashboardUID, uid, err := unpackPublicDashboardID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*client)
+		publicDashboard, err := client.publicDashboard(dashboardUID)
+		if err != nil {
+			return fmt.Errorf("error getting public dashboard: %s", err)
+		}
+		if publicDashboard.UID != uid {
+			return fmt.Errorf("expected public dashboard %s, got %s", uid, publicDashboard.UID)
+		}
+		if publicDashboard.IsEnabled != enabled {
+			return fmt.Errorf("expected public dashboard to have isEnabled=%t", enabled)
+		}
+		return nil
+	}
+}
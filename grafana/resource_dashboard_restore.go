@@ -0,0 +1,97 @@
+package grafana
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceDashboardRestore() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Restores a previous version of a dashboard. Restoring creates a new version of the dashboard with the content of the restored one.
+The restore happens when the resource is created, and again whenever ` + "`version`" + ` changes. Destroying the resource does not change the dashboard.
+
+This should not be used on dashboards managed by the ` + "`grafana_dashboard`" + ` resource, which would revert the restore on its next apply.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/build-dashboards/manage-version-history/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/dashboard_versions/#restore-dashboard)
+`,
+
+		CreateContext: CreateDashboardRestore,
+		ReadContext:   ReadDashboardRestore,
+		UpdateContext: UpdateDashboardRestore,
+		DeleteContext: DeleteDashboardRestore,
+
+		Schema: map[string]*schema.Schema{
+			"dashboard_uid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The UID of the dashboard to restore.",
+			},
+			"version": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The version of the dashboard to restore.",
+			},
+			"dashboard_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The version of the dashboard created by the restore.",
+			},
+		},
+	}
+}
+
+func CreateDashboardRestore(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId(d.Get("dashboard_uid").(string))
+	return UpdateDashboardRestore(ctx, d, meta)
+}
+
+func UpdateDashboardRestore(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	dashboard, err := client.gapi.DashboardByUID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := client.restoreDashboardVersion(int64(dashboard.Model["id"].(float64)), int64(d.Get("version").(int))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	restored, err := client.gapi.DashboardByUID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("dashboard_version", int64(restored.Model["version"].(float64)))
+
+	return ReadDashboardRestore(ctx, d, meta)
+}
+
+func ReadDashboardRestore(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client).gapi
+
+	// The restore itself can't be read back, only the dashboard it applies to
+	if _, err := client.DashboardByUID(d.Id()); err != nil {
+		if strings.HasPrefix(err.Error(), "status: 404") {
+			log.Printf("[WARN] removing dashboard restore %s from state because the dashboard no longer exists in grafana", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	d.Set("dashboard_uid", d.Id())
+
+	return nil
+}
+
+func DeleteDashboardRestore(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Restores can't be undone, the dashboard is left as is
+	return nil
+}
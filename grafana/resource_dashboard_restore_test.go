@@ -0,0 +1,88 @@
+package grafana
+
+import (
+	"fmt"
+	"testing"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDashboardRestore_basic(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+
+	var dashboard gapi.Dashboard
+
+	// Creates versions 1 to 3 of the dashboard, with different titles
+	saveVersions := func() {
+		client := testAccProvider.Meta().(*client).gapi
+		for i := 1; i <= 3; i++ {
+			_, err := client.NewDashboard(gapi.Dashboard{
+				Model:     map[string]interface{}{"uid": "restore-test", "title": fmt.Sprintf("Restore Test %d", i)},
+				Overwrite: true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccDashboardRestoreCheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: saveVersions,
+				Config:    testAccDashboardRestoreConfig(1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboard_restore.test", "id", "restore-test"),
+					resource.TestCheckResourceAttr("grafana_dashboard_restore.test", "dashboard_version", "4"),
+					testAccDashboardRestoreCheckTitle(&dashboard, "Restore Test 1"),
+				),
+			},
+			{
+				Config: testAccDashboardRestoreConfig(3),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboard_restore.test", "dashboard_version", "5"),
+					testAccDashboardRestoreCheckTitle(&dashboard, "Restore Test 3"),
+				),
+			},
+		},
+	})
+}
+
+// Destroying a restore leaves the dashboard as is. It isn't managed by Terraform so it's deleted here.
+func testAccDashboardRestoreCheckDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*client).gapi
+		if _, err := client.DashboardByUID("restore-test"); err != nil {
+			return fmt.Errorf("dashboard should still exist: %s", err)
+		}
+		return client.DeleteDashboardByUID("restore-test")
+	}
+}
+
+func testAccDashboardRestoreCheckTitle(dashboard *gapi.Dashboard, title string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*client).gapi
+		gotDashboard, err := client.DashboardByUID("restore-test")
+		if err != nil {
+			return fmt.Errorf("error getting dashboard: %s", err)
+		}
+		*dashboard = *gotDashboard
+		if gotTitle := dashboard.Model["title"]; gotTitle != title {
+			return fmt.Errorf("expected dashboard title %q, got %q", title, gotTitle)
+		}
+		return nil
+	}
+}
+
+func testAccDashboardRestoreConfig(version int) string {
+	return fmt.Sprintf(`
+resource "grafana_dashboard_restore" "test" {
+  dashboard_uid = "restore-test"
+  version       = %d
+}
+`, version)
+}
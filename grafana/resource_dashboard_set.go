@@ -0,0 +1,312 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceDashboardSet() *schema.Resource {
+	return &schema.Resource{
+
+		Description: `
+Manages a set of Grafana dashboards in a folder, as a single resource.
+
+This is meant for large numbers of dashboards, such as all the dashboards of a directory. Only the dashboards that changed are created, updated or deleted,
+and the requests are sent in parallel. The state holds a SHA256 hash of each dashboard rather than its JSON model.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/http_api/dashboard/)
+`,
+
+		CreateContext: CreateDashboardSet,
+		ReadContext:   ReadDashboardSet,
+		UpdateContext: UpdateDashboardSet,
+		DeleteContext: DeleteDashboardSet,
+
+		Schema: map[string]*schema.Schema{
+			"folder": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The id of the folder to save the dashboards in. This attribute is a string to reflect the type of the folder's id.",
+				ValidateFunc: validation.StringMatch(idRegexp, "must be a valid folder id"),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return old == "0" && new == "" || old == "" && new == "0"
+				},
+			},
+			"dashboards": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "The dashboard model JSONs, by dashboard uid. The `uid` of the models is set from the keys of the map. " +
+					"Only the SHA256 hash of each model is stored in the state.",
+				ValidateFunc:     validateDashboardSetDashboards,
+				DiffSuppressFunc: suppressDashboardSetDiff,
+			},
+			"parallelism": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntBetween(1, 100),
+				Description:  "The maximum number of dashboards created, updated, read or deleted in parallel.",
+			},
+			"overwrite": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Set to true to overwrite existing dashboards with the same uid, or the same title in the folder, when adding dashboards to the set.",
+			},
+		},
+	}
+}
+
+func CreateDashboardSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId(resource.UniqueId())
+	return applyDashboardSet(d, meta, map[string]interface{}{}, false)
+}
+
+func ReadDashboardSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client).gapi
+	folderID, err := dashboardSetFolderID(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	hashes := d.Get("dashboards").(map[string]interface{})
+	var mutex sync.Mutex
+	errs := runDashboardSetOperations(d.Get("parallelism").(int), mapKeys(hashes), func(uid string) error {
+		dashboard, err := client.DashboardByUID(uid)
+		if err != nil && !strings.HasPrefix(err.Error(), "status: 404") {
+			return err
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		switch {
+		case err != nil:
+			// Deleted dashboards are created again
+			delete(hashes, uid)
+		case dashboard.FolderID != folderID:
+			// Moved dashboards are saved again
+			hashes[uid] = ""
+		default:
+			hashes[uid] = dashboardModelHash(dashboard.Model)
+		}
+		return nil
+	})
+	if len(errs) > 0 {
+		return dashboardSetDiags("reading", errs)
+	}
+
+	d.Set("dashboards", hashes)
+	return nil
+}
+
+func UpdateDashboardSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	oldHashes, _ := d.GetChange("dashboards")
+	return applyDashboardSet(d, meta, oldHashes.(map[string]interface{}), d.HasChange("folder"))
+}
+
+func DeleteDashboardSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client).gapi
+	hashes := d.Get("dashboards").(map[string]interface{})
+	errs := runDashboardSetOperations(d.Get("parallelism").(int), mapKeys(hashes), func(uid string) error {
+		if err := client.DeleteDashboardByUID(uid); err != nil && !strings.HasPrefix(err.Error(), "status: 404") {
+			return err
+		}
+		return nil
+	})
+	return dashboardSetDiags("deleting", errs)
+}
+
+// applyDashboardSet saves the configured dashboards whose hash differs from the one in oldHashes (all the dashboards
+// if saveAll is set), and deletes the dashboards that are no longer configured. The state is updated with the
+// dashboards that were saved or deleted successfully.
+func applyDashboardSet(d *schema.ResourceData, meta interface{}, oldHashes map[string]interface{}, saveAll bool) diag.Diagnostics {
+	client := meta.(*client).gapi
+	folderID, err := dashboardSetFolderID(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Unchanged dashboards only have their hash in the planned state, so the models are read from the configuration
+	models := map[string]map[string]interface{}{}
+	hashes := map[string]interface{}{}
+	dashboards := d.GetRawConfig().GetAttr("dashboards")
+	if !dashboards.IsNull() {
+		for uid, config := range dashboards.AsValueMap() {
+			if !config.IsKnown() || config.IsNull() {
+				return diag.Errorf("the model of dashboard %q is not known", uid)
+			}
+			model, err := dashboardSetModel(uid, config.AsString())
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			models[uid] = model
+			hashes[uid] = dashboardSetHash(uid, config.AsString())
+		}
+	}
+
+	var toSave, toDelete []string
+	for uid, hash := range hashes {
+		if oldHash, ok := oldHashes[uid]; !ok || oldHash != hash || saveAll {
+			toSave = append(toSave, uid)
+		}
+	}
+	for uid := range oldHashes {
+		if _, ok := models[uid]; !ok {
+			toDelete = append(toDelete, uid)
+		}
+	}
+
+	parallelism := d.Get("parallelism").(int)
+	overwrite := d.Get("overwrite").(bool)
+	saveErrs := runDashboardSetOperations(parallelism, toSave, func(uid string) error {
+		_, existed := oldHashes[uid]
+		_, err := client.NewDashboard(gapi.Dashboard{
+			Model:     models[uid],
+			FolderID:  folderID,
+			Overwrite: existed || overwrite,
+		})
+		return err
+	})
+	deleteErrs := runDashboardSetOperations(parallelism, toDelete, func(uid string) error {
+		if err := client.DeleteDashboardByUID(uid); err != nil && !strings.HasPrefix(err.Error(), "status: 404") {
+			return err
+		}
+		return nil
+	})
+
+	// Failed operations keep the previous state of the dashboard
+	for uid := range saveErrs {
+		if oldHash, ok := oldHashes[uid]; ok {
+			hashes[uid] = oldHash
+		} else {
+			delete(hashes, uid)
+		}
+	}
+	for uid := range deleteErrs {
+		hashes[uid] = oldHashes[uid]
+	}
+	// Errors are returned with the updated hashes rather than in partial mode, which would discard the dashboards that were saved or deleted
+	d.Set("dashboards", hashes)
+
+	return append(dashboardSetDiags("saving", saveErrs), dashboardSetDiags("deleting", deleteErrs)...)
+}
+
+// dashboardSetModel parses the configured model of a dashboard of the set, and sets its uid.
+func dashboardSetModel(uid, configJSON string) (map[string]interface{}, error) {
+	model, err := unmarshalDashboardConfigJSON(configJSON)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the model of dashboard %q: %s", uid, err)
+	}
+	model["uid"] = uid
+	delete(model, "id")
+	delete(model, "version")
+	return model, nil
+}
+
+func dashboardSetFolderID(d *schema.ResourceData) (int64, error) {
+	folder := d.Get("folder").(string)
+	if folder == "" {
+		return 0, nil
+	}
+	folderID, err := strconv.ParseInt(folder, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing folder: %s", err)
+	}
+	return folderID, nil
+}
+
+func validateDashboardSetDashboards(i interface{}, k string) ([]string, []error) {
+	var errs []error
+	for uid, config := range i.(map[string]interface{}) {
+		if len(uid) > dashboardMaxUIDLength {
+			errs = append(errs, fmt.Errorf("%s: uid %q is longer than %d characters", k, uid, dashboardMaxUIDLength))
+		}
+		configJSON, _ := config.(string)
+		model, err := unmarshalDashboardConfigJSON(configJSON)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: the model of dashboard %q is not valid JSON: %s", k, uid, err))
+			continue
+		}
+		if modelUID, ok := model["uid"].(string); ok && modelUID != uid {
+			errs = append(errs, fmt.Errorf("%s: the model of dashboard %q has uid %q, it must match the key or be omitted", k, uid, modelUID))
+		}
+	}
+	return nil, errs
+}
+
+// dashboardSetHash returns the hash stored in the state for a configured model. It is empty if the model is not valid.
+func dashboardSetHash(uid, configJSON string) string {
+	model, err := dashboardSetModel(uid, configJSON)
+	if err != nil {
+		return ""
+	}
+	return dashboardModelHash(model)
+}
+
+// suppressDashboardSetDiff compares the hashes in the state to the hashes of the configured models.
+func suppressDashboardSetDiff(k, old, new string, d *schema.ResourceData) bool {
+	if strings.HasSuffix(k, ".%") || old == "" {
+		return false
+	}
+	return dashboardSetHash(strings.TrimPrefix(k, "dashboards."), new) == old
+}
+
+// runDashboardSetOperations calls fn for each uid, with at most parallelism calls at the same time.
+// It returns the errors by uid.
+func runDashboardSetOperations(parallelism int, uids []string, fn func(uid string) error) map[string]error {
+	var (
+		wg     sync.WaitGroup
+		mutex  sync.Mutex
+		errs   = map[string]error{}
+		tokens = make(chan struct{}, parallelism)
+	)
+	for _, uid := range uids {
+		wg.Add(1)
+		tokens <- struct{}{}
+		go func(uid string) {
+			defer wg.Done()
+			defer func() { <-tokens }()
+			if err := fn(uid); err != nil {
+				mutex.Lock()
+				errs[uid] = err
+				mutex.Unlock()
+			}
+		}(uid)
+	}
+	wg.Wait()
+	return errs
+}
+
+func dashboardSetDiags(operation string, errs map[string]error) diag.Diagnostics {
+	uids := make([]string, 0, len(errs))
+	for uid := range errs {
+		uids = append(uids, uid)
+	}
+	sort.Strings(uids)
+
+	var diags diag.Diagnostics
+	for _, uid := range uids {
+		diags = append(diags, diag.Errorf("error %s dashboard %q: %s", operation, uid, errs[uid])...)
+	}
+	return diags
+}
+
+func mapKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,254 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDashboardSet_basic(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+
+	var folder gapi.Folder
+	example := testAccExample(t, "resources/grafana_dashboard_set/resource.tf")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccFolderCheckDestroy(&folder),
+			testAccDashboardSetCheckDestroy("team-overview", "team-latency", "team-errors"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: example,
+				Check: resource.ComposeTestCheckFunc(
+					testAccFolderCheckExists("grafana_folder.team", &folder),
+					testAccDashboardSetCheckExists("grafana_dashboard_set.team", &folder, map[string]string{
+						"team-overview": "Team Overview",
+						"team-latency":  "Team Latency",
+					}),
+					resource.TestCheckResourceAttr("grafana_dashboard_set.team", "dashboards.%", "2"),
+					resource.TestMatchResourceAttr("grafana_dashboard_set.team", "dashboards.team-overview", sha256Regexp),
+					resource.TestMatchResourceAttr("grafana_dashboard_set.team", "dashboards.team-latency", sha256Regexp),
+				),
+			},
+			{
+				// Update a dashboard, remove one and add one
+				Config: strings.NewReplacer(
+					`title = "Team Overview"`, `title = "Team Overview v2"`,
+					`"team-latency" = jsonencode({
+      title = "Team Latency"`, `"team-errors" = jsonencode({
+      title = "Team Errors"`,
+				).Replace(example),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardSetCheckExists("grafana_dashboard_set.team", &folder, map[string]string{
+						"team-overview": "Team Overview v2",
+						"team-errors":   "Team Errors",
+					}),
+					testAccDashboardSetCheckDestroy("team-latency"),
+					resource.TestCheckResourceAttr("grafana_dashboard_set.team", "dashboards.%", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDashboardSetCheckExists(rn string, folder *gapi.Folder, titles map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		for uid, title := range titles {
+			dashboard, err := client.DashboardByUID(uid)
+			if err != nil {
+				return fmt.Errorf("error getting dashboard %s: %s", uid, err)
+			}
+			if dashboard.Model["title"] != title {
+				return fmt.Errorf("expected dashboard %s to have title %q, got %q", uid, title, dashboard.Model["title"])
+			}
+			if dashboard.FolderID != folder.ID {
+				return fmt.Errorf("expected dashboard %s to be in folder %d, got %d", uid, folder.ID, dashboard.FolderID)
+			}
+			if hash := rs.Primary.Attributes["dashboards."+uid]; hash != dashboardModelHash(dashboard.Model) {
+				return fmt.Errorf("expected the hash of dashboard %s in state to match the dashboard in Grafana, got %s", uid, hash)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccDashboardSetCheckDestroy(uids ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*client).gapi
+		for _, uid := range uids {
+			if _, err := client.DashboardByUID(uid); err == nil {
+				return fmt.Errorf("dashboard %s still exists", uid)
+			}
+		}
+		return nil
+	}
+}
+
+func TestRunDashboardSetOperations(t *testing.T) {
+	IsUnitTest(t)
+
+	var uids []string
+	for i := 0; i < 20; i++ {
+		uids = append(uids, strconv.Itoa(i))
+	}
+
+	var mutex sync.Mutex
+	running, maxRunning := 0, 0
+	errs := runDashboardSetOperations(3, uids, func(uid string) error {
+		mutex.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mutex.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mutex.Lock()
+		running--
+		mutex.Unlock()
+		if uid == "7" {
+			return fmt.Errorf("failed")
+		}
+		return nil
+	})
+
+	if maxRunning != 3 {
+		t.Errorf("expected 3 operations to run in parallel, got %d", maxRunning)
+	}
+	if len(errs) != 1 || errs["7"] == nil {
+		t.Errorf("expected an error for uid 7, got %v", errs)
+	}
+}
+
+func TestApplyDashboardSetPartialFailure(t *testing.T) {
+	IsUnitTest(t)
+
+	// Saving the dashboards whose uid starts with "broken" fails
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Dashboard map[string]interface{} `json:"dashboard"`
+		}
+		if r.URL.Path != "/api/dashboards/db" || json.NewDecoder(r.Body).Decode(&body) != nil {
+			http.NotFound(w, r)
+			return
+		}
+		uid, _ := body.Dashboard["uid"].(string)
+		if strings.HasPrefix(uid, "broken") {
+			http.Error(w, `{"message": "failed"}`, http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"id": 1, "uid": %q, "status": "success"}`, uid)
+	}))
+	defer server.Close()
+	gapiClient, err := gapi.New(server.URL, gapi.Config{Client: server.Client()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := &client{gapi: gapiClient}
+
+	dashboards := map[string]string{
+		"saved":      `{"title": "Saved"}`,
+		"broken":     `{"title": "Broken"}`,
+		"broken-new": `{"title": "Broken New"}`,
+	}
+	config := map[string]interface{}{"dashboards": map[string]interface{}{}}
+	for uid, model := range dashboards {
+		config["dashboards"].(map[string]interface{})[uid] = model
+	}
+	rawConfigJSON, _ := json.Marshal(map[string]interface{}{"id": nil, "folder": nil, "parallelism": nil, "overwrite": nil, "dashboards": dashboards})
+
+	for _, tc := range []struct {
+		name     string
+		state    *terraform.InstanceState
+		expected map[string]string
+	}{
+		{
+			name:  "create",
+			state: nil,
+			// The new dashboards that failed are left out, so that they are created again
+			expected: map[string]string{"saved": dashboardSetHash("saved", dashboards["saved"])},
+		},
+		{
+			name: "update",
+			state: &terraform.InstanceState{ID: "set", Attributes: map[string]string{
+				"id": "set", "parallelism": "10", "dashboards.%": "2", "dashboards.saved": "old-saved", "dashboards.broken": "old-broken",
+			}},
+			// The existing dashboards that failed keep their previous hash
+			expected: map[string]string{"saved": dashboardSetHash("saved", dashboards["saved"]), "broken": "old-broken"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := ResourceDashboardSet()
+			diff, err := r.SimpleDiff(context.Background(), tc.state, terraform.NewResourceConfigRaw(config), meta)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff.RawConfig, err = ctyjson.Unmarshal(rawConfigJSON, r.CoreConfigSchema().ImpliedType()); err != nil {
+				t.Fatal(err)
+			}
+
+			state, diags := r.Apply(context.Background(), tc.state, diff, meta)
+			if len(diags) != 2 || !diags.HasError() {
+				t.Errorf("expected errors for the 2 broken dashboards, got %v", diags)
+			}
+			if state == nil {
+				t.Fatal("expected the state to be kept")
+			}
+
+			got := map[string]string{}
+			for k, v := range state.Attributes {
+				if strings.HasPrefix(k, "dashboards.") && k != "dashboards.%" {
+					got[strings.TrimPrefix(k, "dashboards.")] = v
+				}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tc.expected) {
+				t.Errorf("expected the dashboards %v in the state, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSuppressDashboardSetDiff(t *testing.T) {
+	IsUnitTest(t)
+
+	hash := dashboardSetHash("team", `{"title": "Team", "panels": [{"id": 1, "type": "text"}]}`)
+	for _, tc := range []struct {
+		key, old, new string
+		suppress      bool
+	}{
+		{key: "dashboards.team", old: hash, new: `{"panels":[{"type":"text","id":2}],"title":"Team"}`, suppress: true},
+		{key: "dashboards.team", old: hash, new: `{"title":"Team","uid":"team","version":3}`, suppress: false},
+		{key: "dashboards.team", old: hash, new: `{"title":"Team","panels":[{"type":"text"}],"uid":"team","version":3}`, suppress: true},
+		{key: "dashboards.other", old: hash, new: `{"title":"Team","panels":[{"type":"text"}]}`, suppress: false},
+		{key: "dashboards.team", old: "", new: `{"title":"Team","panels":[{"type":"text"}]}`, suppress: false},
+		{key: "dashboards.%", old: "2", new: "2", suppress: false},
+	} {
+		if got := suppressDashboardSetDiff(tc.key, tc.old, tc.new, nil); got != tc.suppress {
+			t.Errorf("%s: %s => %s: expected suppress=%t, got %t", tc.key, tc.old, tc.new, tc.suppress, got)
+		}
+	}
+}
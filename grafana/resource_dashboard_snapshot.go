@@ -0,0 +1,183 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dashboardSnapshotNeverExpiresYears is the number of years after which the expiration of a snapshot means that it never expires.
+// Grafana sets it 50 years ahead, this leaves room for the clock skew and the rounding.
+const dashboardSnapshotNeverExpiresYears = 49
+
+func ResourceDashboardSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages dashboard snapshots. Snapshots can't be modified, so changing any attribute creates a new snapshot.
+Expired snapshots are removed from the state and created again on the next apply.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/share-dashboards-panels/#publish-a-snapshot)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/snapshot/)
+`,
+
+		CreateContext: CreateDashboardSnapshot,
+		ReadContext:   ReadDashboardSnapshot,
+		DeleteContext: DeleteDashboardSnapshot,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"config_json": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: SuppressEquivalentJSONDiffs,
+				Description: "The dashboard model JSON to snapshot, such as the `config_json` attribute of the `grafana_dashboard` resource or data source. " +
+					"The panels may hold the data to display in their `snapshotData` attribute.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The name of the snapshot.",
+			},
+			"expires": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Number of seconds after which the snapshot expires. `0` means it never expires.",
+			},
+			"external": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether the snapshot is stored on the external snapshot server configured in Grafana.",
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The key of the snapshot, used in its URL.",
+			},
+			"delete_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The key used to delete the snapshot. It isn't known for imported snapshots.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the snapshot.",
+			},
+		},
+	}
+}
+
+func CreateDashboardSnapshot(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	dashboard, err := unmarshalDashboardConfigJSON(d.Get("config_json").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	snapshot, err := client.newDashboardSnapshot(&dashboardSnapshotRequest{
+		Dashboard: dashboard,
+		Name:      d.Get("name").(string),
+		Expires:   int64(d.Get("expires").(int)),
+		External:  d.Get("external").(bool),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(snapshot.Key)
+	d.Set("key", snapshot.Key)
+	d.Set("delete_key", snapshot.DeleteKey)
+	d.Set("url", snapshot.URL)
+
+	return ReadDashboardSnapshot(ctx, d, meta)
+}
+
+func ReadDashboardSnapshot(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	snapshot, err := client.getDashboardSnapshot(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if snapshot == nil {
+		log.Printf("[WARN] removing dashboard snapshot %s from state because it no longer exists in grafana, it may have expired", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("key", d.Id())
+	if d.Get("url").(string) == "" {
+		d.Set("url", strings.TrimRight(client.gapiURL, "/")+"/dashboard/snapshot/"+d.Id())
+	}
+
+	// Snapshots can't be modified, so the configured attributes are only read back when they are unknown, after an import
+	if d.Get("config_json").(string) == "" {
+		if err := readImportedDashboardSnapshot(client, d, snapshot); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// readImportedDashboardSnapshot sets the configured attributes of an imported snapshot.
+func readImportedDashboardSnapshot(client *client, d *schema.ResourceData, snapshot *dashboardSnapshotModel) error {
+	configJSON, err := json.Marshal(snapshot.Dashboard)
+	if err != nil {
+		return err
+	}
+	d.Set("config_json", string(configJSON))
+
+	// Grafana sets the expiration of the snapshots that never expire 50 years ahead
+	expires := int64(snapshot.Meta.Expires.Sub(snapshot.Meta.Created).Round(time.Second) / time.Second)
+	if expires < 0 || snapshot.Meta.Expires.After(snapshot.Meta.Created.AddDate(dashboardSnapshotNeverExpiresYears, 0, 0)) {
+		expires = 0
+	}
+	d.Set("expires", expires)
+
+	snapshots, err := client.dashboardSnapshots()
+	if err != nil {
+		return err
+	}
+	for _, s := range snapshots {
+		if s.Key == d.Id() {
+			d.Set("name", s.Name)
+			d.Set("external", s.External)
+		}
+	}
+	return nil
+}
+
+func DeleteDashboardSnapshot(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	var err error
+	if deleteKey := d.Get("delete_key").(string); deleteKey != "" {
+		err = client.deleteDashboardSnapshotWithDeleteKey(deleteKey)
+	} else {
+		// Imported snapshots can only be deleted by key, which requires more permissions
+		err = client.deleteDashboardSnapshot(d.Id())
+	}
+	if err != nil && !strings.HasPrefix(err.Error(), "status: 404") {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDashboardSnapshot_basic(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+
+	var dashboard gapi.Dashboard
+	var key string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccDashboardCheckDestroy(&dashboard),
+			testAccDashboardSnapshotCheckDestroy(&key),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_dashboard_snapshot/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.metrics", &dashboard),
+					testAccDashboardSnapshotCheckExists("grafana_dashboard_snapshot.metrics", &key),
+					resource.TestCheckResourceAttr("grafana_dashboard_snapshot.metrics", "name", "Production Overview snapshot"),
+					resource.TestCheckResourceAttr("grafana_dashboard_snapshot.metrics", "expires", "3600"),
+					resource.TestCheckResourceAttr("grafana_dashboard_snapshot.metrics", "external", "false"),
+					resource.TestCheckResourceAttrSet("grafana_dashboard_snapshot.metrics", "delete_key"),
+					resource.TestMatchResourceAttr("grafana_dashboard_snapshot.metrics", "url", regexp.MustCompile("^"+regexp.QuoteMeta(strings.TrimRight(os.Getenv("GRAFANA_URL"), "/"))+"/dashboard/snapshot/.+$")),
+				),
+			},
+			{
+				ResourceName:            "grafana_dashboard_snapshot.metrics",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"config_json", "delete_key"},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported snapshot, got %d", len(states))
+					}
+					dashboard, err := unmarshalDashboardConfigJSON(states[0].Attributes["config_json"])
+					if err != nil {
+						return err
+					}
+					if dashboard["title"] != "Production Overview" {
+						return fmt.Errorf("expected the dashboard of the snapshot to be imported, got %s", states[0].Attributes["config_json"])
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestReadImportedDashboardSnapshot(t *testing.T) {
+	IsUnitTest(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/snapshots/abc":
+			fmt.Fprint(w, `{"dashboard": {"title": "Production Overview", "uid": "snapshot-metrics"}, "meta": {"created": "2022-10-18T10:00:00Z", "expires": "2022-10-18T11:00:00Z"}}`)
+		case "/api/snapshots/never":
+			fmt.Fprint(w, `{"dashboard": {"title": "Forever"}, "meta": {"created": "2022-10-18T10:00:00Z", "expires": "2072-10-18T10:00:00Z"}}`)
+		case "/api/dashboard/snapshots":
+			fmt.Fprint(w, `[{"key": "abc", "name": "Production Overview snapshot", "external": true}, {"key": "never", "name": "Forever"}]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	c := &client{gapiURL: server.URL, gapiConfig: &gapi.Config{Client: server.Client()}}
+
+	for _, tc := range []struct {
+		key        string
+		configJSON string
+		name       string
+		expires    int
+		external   bool
+	}{
+		{key: "abc", configJSON: `{"title":"Production Overview","uid":"snapshot-metrics"}`, name: "Production Overview snapshot", expires: 3600, external: true},
+		{key: "never", configJSON: `{"title":"Forever"}`, name: "Forever", expires: 0},
+	} {
+		t.Run(tc.key, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, ResourceDashboardSnapshot().Schema, map[string]interface{}{})
+			d.SetId(tc.key)
+			if diags := ReadDashboardSnapshot(context.Background(), d, c); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if got := d.Get("config_json").(string); got != tc.configJSON {
+				t.Errorf("expected config_json %s, got %s", tc.configJSON, got)
+			}
+			if got := d.Get("name").(string); got != tc.name {
+				t.Errorf("expected name %q, got %q", tc.name, got)
+			}
+			if got := d.Get("expires").(int); got != tc.expires {
+				t.Errorf("expected expires %d, got %d", tc.expires, got)
+			}
+			if got := d.Get("external").(bool); got != tc.external {
+				t.Errorf("expected external %t, got %t", tc.external, got)
+			}
+			if got := d.Get("url").(string); got != server.URL+"/dashboard/snapshot/"+tc.key {
+				t.Errorf("unexpected url %s", got)
+			}
+		})
+	}
+}
+
+func testAccDashboardSnapshotCheckExists(rn string, key *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		client := testAccProvider.Meta().(*client)
+		exists, err := client.dashboardSnapshotExists(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting dashboard snapshot: %s", err)
+		}
+		if !exists {
+			return fmt.Errorf("dashboard snapshot %s does not exist", rs.Primary.ID)
+		}
+		if rs.Primary.Attributes["key"] != rs.Primary.ID {
+			return fmt.Errorf("expected key %s, got %s", rs.Primary.ID, rs.Primary.Attributes["key"])
+		}
+		*key = rs.Primary.ID
+		return nil
+	}
+}
+
+func testAccDashboardSnapshotCheckDestroy(key *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*client)
+		exists, err := client.dashboardSnapshotExists(*key)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("dashboard snapshot %s still exists", *key)
+		}
+		return nil
+	}
+}
@@ -3,6 +3,7 @@ package grafana
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -180,6 +181,20 @@ func TestAccDashboard_folder(t *testing.T) {
 	})
 }
 
+func TestAccDashboard_strict_validation(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccExample(t, "resources/grafana_dashboard/_acc_strict_validation.tf"),
+				ExpectError: regexp.MustCompile(`panel "Unknown data source" uses data source "does-not-exist", which does not exist`),
+			},
+		},
+	})
+}
+
 func testAccDashboardCheckExists(rn string, dashboard *gapi.Dashboard) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -248,6 +263,11 @@ func Test_normalizeDashboardConfigJSON(t *testing.T) {
 		t.Error(err)
 	}
 	expectedPanels := fmt.Sprintf("{\"panels\":[{\"libraryPanel\":{\"name\":\"%s\",\"uid\":\"%s\"}}]}", "test", "test")
+	givenRowPanels, err := unmarshalDashboardConfigJSON(`{"panels":[{"id":1,"type":"row","panels":[{"id":2,"libraryPanel":{"name":"test","uid":"test","version":3}}]}]}`)
+	if err != nil {
+		t.Error(err)
+	}
+	expectedRowPanels := `{"panels":[{"panels":[{"libraryPanel":{"name":"test","uid":"test"}}],"type":"row"}]}`
 
 	tests := []struct {
 		name string
@@ -284,6 +304,11 @@ func Test_normalizeDashboardConfigJSON(t *testing.T) {
 			args: args{config: givenPanels},
 			want: expectedPanels,
 		},
+		{
+			name: "panels nested in rows are normalized",
+			args: args{config: givenRowPanels},
+			want: expectedRowPanels,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -293,3 +318,50 @@ func Test_normalizeDashboardConfigJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestAccDashboard_ignore_paths(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+
+	var dashboard gapi.Dashboard
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccDashboardCheckDestroy(&dashboard),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_dashboard/_acc_ignore_paths.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "config_json", `{"title":"Ignored Paths","uid":"ignore-paths"}`),
+				),
+			},
+			{
+				// Changes made in Grafana to the ignored paths don't cause a diff
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*client).gapi
+					dashboard.Model["refresh"] = "5m"
+					dashboard.Overwrite = true
+					if _, err := client.NewDashboard(dashboard); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:   testAccExample(t, "resources/grafana_dashboard/_acc_ignore_paths.tf"),
+				PlanOnly: true,
+			},
+			{
+				// The values of the ignored paths are kept when the dashboard is updated
+				Config: testAccExample(t, "resources/grafana_dashboard/_acc_ignore_paths_update.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "config_json", `{"title":"Ignored Paths Updated","uid":"ignore-paths"}`),
+					func(s *terraform.State) error {
+						if refresh := dashboard.Model["refresh"]; refresh != "5m" {
+							return fmt.Errorf("expected refresh to be kept at 5m, got %v", refresh)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -33,6 +33,7 @@ source selected (via the 'type' argument).
 		UpdateContext:  UpdateDataSource,
 		DeleteContext:  DeleteDataSource,
 		ReadContext:    ReadDataSource,
+		CustomizeDiff:  registerPlannedName(plannedDatasourceUIDs, "uid"),
 		StateUpgraders: []schema.StateUpgrader{resourceDataSourceV0Upgrader},
 		SchemaVersion:  1,
 
@@ -18,7 +18,11 @@ func ResourceDatasourcePermission() *schema.Resource {
 	return &schema.Resource{
 
 		Description: `
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/data-source-management/#data-source-permissions)
 * [HTTP API](https://grafana.com/docs/grafana/latest/http_api/datasource_permissions/)
+
+When the data source is identified by its UID, permissions are managed through the access control API.
+It supports the Query, Edit and Admin permissions, as well as built-in roles and service accounts. This requires Grafana Enterprise 9.0.0 or later.
 `,
 
 		CreateContext: UpdateDatasourcePermissions,
@@ -28,10 +32,19 @@ func ResourceDatasourcePermission() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"datasource_id": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				ForceNew:    true,
-				Description: "ID of the datasource to apply permissions to.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"datasource_id", "datasource_uid"},
+				Deprecated:   "Use `datasource_uid` instead.",
+				Description:  "ID of the datasource to apply permissions to. Only the `Query` permission can be managed for users and teams with this attribute.",
+			},
+			"datasource_uid": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"datasource_id", "datasource_uid"},
+				Description:  "UID of the datasource to apply permissions to.",
 			},
 			"permissions": {
 				Type:        schema.TypeSet,
@@ -51,11 +64,24 @@ func ResourceDatasourcePermission() *schema.Resource {
 							Default:     0,
 							Description: "ID of the user to manage permissions for.",
 						},
+						"service_account_id": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     0,
+							Description: "ID of the service account to manage permissions for.",
+						},
+						"built_in_role": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "",
+							ValidateFunc: validation.StringInSlice([]string{"", "Viewer", "Editor", "Admin"}, false),
+							Description:  "Manage permissions for the `Viewer`, `Editor` or `Admin` built-in roles. Requires `datasource_uid`.",
+						},
 						"permission": {
 							Type:         schema.TypeString,
 							Required:     true,
-							ValidateFunc: validation.StringInSlice([]string{"Query"}, false),
-							Description:  "Permission to associate with item. Must be `Query`.",
+							ValidateFunc: validation.StringInSlice([]string{"Query", "Edit", "Admin"}, false),
+							Description:  "Permission to associate with item. Must be one of `Query`, `Edit` or `Admin`. Only `Query` is supported with `datasource_id`.",
 						},
 					},
 				},
@@ -65,17 +91,30 @@ func ResourceDatasourcePermission() *schema.Resource {
 }
 
 func UpdateDatasourcePermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gapi
-
 	v, ok := d.GetOk("permissions")
 	if !ok {
 		return nil
 	}
+
+	if uid := d.Get("datasource_uid").(string); uid != "" {
+		return updateDatasourceResourcePermissions(ctx, d, meta, uid, v.(*schema.Set).List())
+	}
+
+	client := meta.(*client).gapi
 	datasourceID := int64(d.Get("datasource_id").(int))
 
 	configuredPermissions := []*gapi.DatasourcePermissionAddPayload{}
 	for _, permission := range v.(*schema.Set).List() {
 		permission := permission.(map[string]interface{})
+		if err := validateDatasourcePermissionItem(permission); err != nil {
+			return diag.FromErr(err)
+		}
+		if permission["built_in_role"].(string) != "" {
+			return diag.Errorf("built-in role permissions require `datasource_uid` to be set")
+		}
+		if p := permission["permission"].(string); p != "Query" {
+			return diag.Errorf("the `%s` permission requires `datasource_uid` to be set", p)
+		}
 		permissionItem := gapi.DatasourcePermissionAddPayload{}
 		if permission["team_id"].(int) != -1 {
 			permissionItem.TeamID = int64(permission["team_id"].(int))
@@ -83,6 +122,9 @@ func UpdateDatasourcePermissions(ctx context.Context, d *schema.ResourceData, me
 		if permission["user_id"].(int) != -1 {
 			permissionItem.UserID = int64(permission["user_id"].(int))
 		}
+		if saID := permission["service_account_id"].(int); saID > 0 {
+			permissionItem.UserID = int64(saID)
+		}
 		var err error
 		if permissionItem.Permission, err = mapDatasourcePermissionStringToType(permission["permission"].(string)); err != nil {
 			return diag.FromErr(err)
@@ -99,7 +141,60 @@ func UpdateDatasourcePermissions(ctx context.Context, d *schema.ResourceData, me
 	return ReadDatasourcePermissions(ctx, d, meta)
 }
 
+func updateDatasourceResourcePermissions(ctx context.Context, d *schema.ResourceData, meta interface{}, uid string, permissions []interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	assignments := make([]resourcePermissionAssignment, 0, len(permissions))
+	for _, permission := range permissions {
+		permission := permission.(map[string]interface{})
+		if err := validateDatasourcePermissionItem(permission); err != nil {
+			return diag.FromErr(err)
+		}
+		assignment := resourcePermissionAssignment{
+			TeamID:      int64(permission["team_id"].(int)),
+			UserID:      int64(permission["user_id"].(int)),
+			BuiltInRole: permission["built_in_role"].(string),
+			Permission:  permission["permission"].(string),
+		}
+		// Service accounts are users as far as permissions are concerned
+		if saID := permission["service_account_id"].(int); saID > 0 {
+			assignment.UserID = int64(saID)
+		}
+		assignments = append(assignments, assignment)
+	}
+
+	if err := client.replaceResourcePermissions("datasources", uid, assignments); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(uid)
+
+	return ReadDatasourcePermissions(ctx, d, meta)
+}
+
+// validateDatasourcePermissionItem checks that an item of `permissions` grants its permission to exactly one principal.
+func validateDatasourcePermissionItem(permission map[string]interface{}) error {
+	var principals []string
+	for _, k := range []string{"team_id", "user_id", "service_account_id"} {
+		if id := permission[k].(int); id > 0 {
+			principals = append(principals, fmt.Sprintf("%s = %d", k, id))
+		}
+	}
+	if role := permission["built_in_role"].(string); role != "" {
+		principals = append(principals, fmt.Sprintf("built_in_role = %q", role))
+	}
+	if len(principals) != 1 {
+		return fmt.Errorf("the `%s` permission item with [%s] must set exactly one of `team_id`, `user_id`, `service_account_id` or `built_in_role`",
+			permission["permission"], strings.Join(principals, ", "))
+	}
+	return nil
+}
+
 func ReadDatasourcePermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if uid := d.Get("datasource_uid").(string); uid != "" {
+		return readDatasourceResourcePermissions(ctx, d, meta, uid)
+	}
+
 	client := meta.(*client).gapi
 
 	id, err := strconv.ParseInt(d.Id(), 10, 64)
@@ -123,6 +218,8 @@ func ReadDatasourcePermissions(ctx context.Context, d *schema.ResourceData, meta
 		permissionItem := make(map[string]interface{})
 		permissionItem["team_id"] = permission.TeamID
 		permissionItem["user_id"] = permission.UserID
+		permissionItem["service_account_id"] = 0
+		permissionItem["built_in_role"] = ""
 		if permissionItem["permission"], err = mapDatasourcePermissionTypeToString(permission.Permission); err != nil {
 			return diag.FromErr(err)
 		}
@@ -135,7 +232,54 @@ func ReadDatasourcePermissions(ctx context.Context, d *schema.ResourceData, meta
 	return nil
 }
 
+func readDatasourceResourcePermissions(ctx context.Context, d *schema.ResourceData, meta interface{}, uid string) diag.Diagnostics {
+	client := meta.(*client)
+
+	permissions, err := client.listResourcePermissions("datasources", uid)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "status: 404") {
+			log.Printf("[WARN] removing datasource permissions %s from state because it no longer exists in grafana", uid)
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	permissionItems := []interface{}{}
+	for _, permission := range permissions {
+		if !permission.IsManaged || permission.IsInherited {
+			continue
+		}
+		permissionItem := map[string]interface{}{
+			"team_id":            permission.TeamID,
+			"user_id":            permission.UserID,
+			"service_account_id": 0,
+			"built_in_role":      permission.BuiltInRole,
+			"permission":         permission.Permission,
+		}
+		if permission.IsServiceAccount {
+			permissionItem["user_id"] = 0
+			permissionItem["service_account_id"] = permission.UserID
+		}
+		permissionItems = append(permissionItems, permissionItem)
+	}
+
+	d.Set("datasource_uid", uid)
+	d.Set("permissions", permissionItems)
+
+	return nil
+}
+
 func DeleteDatasourcePermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if uid := d.Get("datasource_uid").(string); uid != "" {
+		err := meta.(*client).replaceResourcePermissions("datasources", uid, nil)
+		if err != nil && !strings.HasPrefix(err.Error(), "status: 404") {
+			return diag.FromErr(err)
+		}
+		return nil
+	}
+
 	client := meta.(*client).gapi
 
 	datasourceID := int64(d.Get("datasource_id").(int))
@@ -0,0 +1,33 @@
+package grafana
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var datasourcePermissionItem = &permissionItemResource{
+	resourceType: "datasources",
+	uidAttribute: "datasource_uid",
+	permissions:  []string{"Query", "Edit", "Admin"},
+}
+
+func ResourceDatasourcePermissionItem() *schema.Resource {
+	return &schema.Resource{
+
+		Description: `
+Manages a single permission item for a data source. Conflicts with the [grafana_data_source_permission](https://registry.terraform.io/providers/grafana/grafana/latest/docs/resources/data_source_permission) resource which manages the entire set of permissions for a data source.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/data-source-management/#data-source-permissions)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/datasource_permissions/)
+
+This resource requires Grafana Enterprise 9.0.0 or later.
+`,
+
+		CreateContext: datasourcePermissionItem.create,
+		ReadContext:   datasourcePermissionItem.read,
+		UpdateContext: datasourcePermissionItem.update,
+		DeleteContext: datasourcePermissionItem.delete,
+		Importer:      datasourcePermissionItem.importer(),
+
+		Schema: datasourcePermissionItem.schema("The UID of the data source."),
+	}
+}
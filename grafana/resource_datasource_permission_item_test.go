@@ -0,0 +1,49 @@
+package grafana
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourcePermissionItem_basic(t *testing.T) {
+	CheckEnterpriseTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=9.0.0")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccPermissionItemCheckDestroy("datasources"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_data_source_permission_item/resource.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccPermissionItemCheckExists("grafana_data_source_permission_item.on_role", "datasources"),
+					testAccPermissionItemCheckExists("grafana_data_source_permission_item.on_team", "datasources"),
+					testAccPermissionItemCheckExists("grafana_data_source_permission_item.on_user", "datasources"),
+					testAccPermissionItemCheckExists("grafana_data_source_permission_item.on_service_account", "datasources"),
+				),
+			},
+			{
+				ResourceName:      "grafana_data_source_permission_item.on_team",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "grafana_data_source_permission_item.on_service_account",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Changing one item's permission doesn't affect the others
+			{
+				Config: testAccExampleWithReplace(t, "resources/grafana_data_source_permission_item/resource.tf", map[string]string{
+					`permission     = "Admin"`: `permission     = "Query"`,
+				}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_data_source_permission_item.on_user", "permission", "Query"),
+					testAccPermissionItemCheckExists("grafana_data_source_permission_item.on_user", "datasources"),
+					testAccPermissionItemCheckExists("grafana_data_source_permission_item.on_team", "datasources"),
+				),
+			},
+		},
+	})
+}
@@ -3,6 +3,7 @@ package grafana
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -20,7 +21,7 @@ func TestAccDatasourcePermission_basic(t *testing.T) {
 		ProviderFactories: testAccProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccExample(t, "resources/grafana_data_source_permission/resource.tf"),
+				Config: testAccExample(t, "resources/grafana_data_source_permission/_acc_legacy.tf"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					testAccDatasourcePermissionsCheckExists("grafana_data_source_permission.fooPermissions", &datasourceID),
 					resource.TestCheckResourceAttr("grafana_data_source_permission.fooPermissions", "permissions.#", "2"),
@@ -34,6 +35,71 @@ func TestAccDatasourcePermission_basic(t *testing.T) {
 	})
 }
 
+func TestAccDatasourcePermission_uid(t *testing.T) {
+	CheckEnterpriseTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=9.0.0")
+
+	datasourceUID := ""
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_data_source_permission/resource.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccDatasourceResourcePermissionsCheck("grafana_data_source_permission.fooPermissions", &datasourceUID, 4),
+					resource.TestCheckResourceAttr("grafana_data_source_permission.fooPermissions", "permissions.#", "4"),
+				),
+			},
+			{
+				Config: testAccExample(t, "resources/grafana_data_source_permission/_acc_resource_remove.tf"),
+				Check: func(s *terraform.State) error {
+					permissions, err := testAccProvider.Meta().(*client).listResourcePermissions("datasources", datasourceUID)
+					if err != nil {
+						// The data source may have been destroyed as well
+						return nil
+					}
+					for _, permission := range permissions {
+						if permission.IsManaged && !permission.IsInherited && (permission.UserID != 0 || permission.TeamID != 0) {
+							return fmt.Errorf("permissions were not empty when expected")
+						}
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccDatasourceResourcePermissionsCheck(rn string, datasourceUID *string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s\n %#v", rn, s.RootModule().Resources)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		permissions, err := testAccProvider.Meta().(*client).listResourcePermissions("datasources", rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting datasource permissions: %s", err)
+		}
+		managed := 0
+		for _, permission := range permissions {
+			if permission.IsManaged && !permission.IsInherited {
+				managed++
+			}
+		}
+		if managed != expected {
+			return fmt.Errorf("expected %d managed permissions, got %d", expected, managed)
+		}
+
+		*datasourceUID = rs.Primary.ID
+		return nil
+	}
+}
+
 //nolint:unused
 func testAccDatasourcePermissionsCheckExists(rn string, datasourceID *int64) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
@@ -82,3 +148,32 @@ func testAccDatasourcePermissionCheckDestroy(datasourceID *int64) resource.TestC
 		return nil
 	}
 }
+
+func TestValidateDatasourcePermissionItem(t *testing.T) {
+	IsUnitTest(t)
+
+	item := func(teamID, userID, serviceAccountID int, builtInRole string) map[string]interface{} {
+		return map[string]interface{}{
+			"team_id":            teamID,
+			"user_id":            userID,
+			"service_account_id": serviceAccountID,
+			"built_in_role":      builtInRole,
+			"permission":         "Query",
+		}
+	}
+
+	for _, valid := range []map[string]interface{}{item(1, 0, 0, ""), item(0, 2, 0, ""), item(0, 0, 3, ""), item(0, 0, 0, "Viewer")} {
+		if err := validateDatasourcePermissionItem(valid); err != nil {
+			t.Errorf("expected %v to be valid, got %s", valid, err)
+		}
+	}
+
+	err := validateDatasourcePermissionItem(item(0, 0, 0, ""))
+	if err == nil || err.Error() != "the `Query` permission item with [] must set exactly one of `team_id`, `user_id`, `service_account_id` or `built_in_role`" {
+		t.Errorf("expected an error for the item without principal, got %v", err)
+	}
+	err = validateDatasourcePermissionItem(item(1, 2, 0, ""))
+	if err == nil || !strings.Contains(err.Error(), "[team_id = 1, user_id = 2]") {
+		t.Errorf("expected an error naming both principals, got %v", err)
+	}
+}
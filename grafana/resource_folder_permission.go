@@ -18,6 +18,8 @@ func ResourceFolderPermission() *schema.Resource {
 		Description: `
 * [Official documentation](https://grafana.com/docs/grafana/latest/permissions/dashboard_folder_permissions/)
 * [HTTP API](https://grafana.com/docs/grafana/latest/http_api/folder_permissions/)
+
+Permissions are managed through the access control API when Grafana has RBAC (Grafana 9+), and through the legacy folder permissions API otherwise.
 `,
 
 		CreateContext: UpdateFolderPermissions,
@@ -56,6 +58,12 @@ func ResourceFolderPermission() *schema.Resource {
 							Default:     0,
 							Description: "ID of the user to manage permissions for.",
 						},
+						"service_account_id": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     0,
+							Description: "ID of the service account to manage permissions for.",
+						},
 						"permission": {
 							Type:         schema.TypeString,
 							Required:     true,
@@ -70,32 +78,24 @@ func ResourceFolderPermission() *schema.Resource {
 }
 
 func UpdateFolderPermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gapi
+	client := meta.(*client)
 
 	v, ok := d.GetOk("permissions")
 	if !ok {
 		return nil
 	}
-	permissionList := gapi.PermissionItems{}
-	for _, permission := range v.(*schema.Set).List() {
-		permission := permission.(map[string]interface{})
-		permissionItem := gapi.PermissionItem{}
-		if permission["role"].(string) != "" {
-			permissionItem.Role = permission["role"].(string)
-		}
-		if permission["team_id"].(int) != -1 {
-			permissionItem.TeamID = int64(permission["team_id"].(int))
-		}
-		if permission["user_id"].(int) != -1 {
-			permissionItem.UserID = int64(permission["user_id"].(int))
-		}
-		permissionItem.Permission = mapPermissionStringToInt64(permission["permission"].(string))
-		permissionList.Items = append(permissionList.Items, &permissionItem)
-	}
 
 	folderUID := d.Get("folder_uid").(string)
 
-	err := client.UpdateFolderPermissions(folderUID, &permissionList)
+	rbac, err := client.resourcePermissionsSupported("folders")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if rbac {
+		err = client.replaceResourcePermissions("folders", folderUID, permissionsToResourceAssignments(v.(*schema.Set).List()))
+	} else {
+		err = client.gapi.UpdateFolderPermissions(folderUID, permissionsToLegacyItems(v.(*schema.Set).List()))
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -106,11 +106,29 @@ func UpdateFolderPermissions(ctx context.Context, d *schema.ResourceData, meta i
 }
 
 func ReadFolderPermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gapi
+	client := meta.(*client)
 
 	folderUID := d.Get("folder_uid").(string)
 
-	folderPermissions, err := client.FolderPermissions(folderUID)
+	rbac, err := client.resourcePermissionsSupported("folders")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if rbac {
+		permissions, err := client.listResourcePermissions("folders", folderUID)
+		if err != nil {
+			if strings.HasPrefix(err.Error(), "status: 404") {
+				log.Printf("[WARN] removing folder %s from state because it no longer exists in grafana", folderUID)
+				d.SetId("")
+				return nil
+			}
+			return diag.FromErr(err)
+		}
+		d.Set("permissions", resourcePermissionsToState(permissions))
+		return nil
+	}
+
+	folderPermissions, err := client.gapi.FolderPermissions(folderUID)
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "status: 404") {
 			log.Printf("[WARN] removing folder %s from state because it no longer exists in grafana", folderUID)
@@ -121,17 +139,12 @@ func ReadFolderPermissions(ctx context.Context, d *schema.ResourceData, meta int
 		return diag.FromErr(err)
 	}
 
+	serviceAccountIDs := configuredServiceAccountIDs(d)
 	permissionItems := make([]interface{}, len(folderPermissions))
 	count := 0
 	for _, permission := range folderPermissions {
 		if permission.FolderUID != "" {
-			permissionItem := make(map[string]interface{})
-			permissionItem["role"] = permission.Role
-			permissionItem["team_id"] = permission.TeamID
-			permissionItem["user_id"] = permission.UserID
-			permissionItem["permission"] = mapPermissionInt64ToString(permission.Permission)
-
-			permissionItems[count] = permissionItem
+			permissionItems[count] = legacyPermissionToState(permission.Role, permission.TeamID, permission.UserID, permission.Permission, serviceAccountIDs)
 			count++
 		}
 	}
@@ -145,12 +158,19 @@ func DeleteFolderPermissions(ctx context.Context, d *schema.ResourceData, meta i
 	// since permissions are tied to folders, we can't really delete the permissions.
 	// we will simply remove all permissions, leaving a folder that only an admin can access.
 	// if for some reason the parent folder doesn't exist, we'll just ignore the error
-	client := meta.(*client).gapi
+	client := meta.(*client)
 
 	folderUID := d.Get("folder_uid").(string)
-	emptyPermissions := gapi.PermissionItems{}
 
-	err := client.UpdateFolderPermissions(folderUID, &emptyPermissions)
+	rbac, err := client.resourcePermissionsSupported("folders")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if rbac {
+		err = client.replaceResourcePermissions("folders", folderUID, nil)
+	} else {
+		err = client.gapi.UpdateFolderPermissions(folderUID, &gapi.PermissionItems{})
+	}
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "status: 404") {
 			d.SetId("")
@@ -162,6 +182,103 @@ func DeleteFolderPermissions(ctx context.Context, d *schema.ResourceData, meta i
 	return nil
 }
 
+// permissionsToLegacyItems converts the `permissions` blocks of the folder and dashboard permission resources
+// to the items expected by the legacy permissions API.
+func permissionsToLegacyItems(permissions []interface{}) *gapi.PermissionItems {
+	permissionList := gapi.PermissionItems{}
+	for _, permission := range permissions {
+		permission := permission.(map[string]interface{})
+		permissionItem := gapi.PermissionItem{}
+		if permission["role"].(string) != "" {
+			permissionItem.Role = permission["role"].(string)
+		}
+		if permission["team_id"].(int) != -1 {
+			permissionItem.TeamID = int64(permission["team_id"].(int))
+		}
+		if permission["user_id"].(int) != -1 {
+			permissionItem.UserID = int64(permission["user_id"].(int))
+		}
+		// Service accounts are users as far as permissions are concerned
+		if permission["service_account_id"].(int) > 0 {
+			permissionItem.UserID = int64(permission["service_account_id"].(int))
+		}
+		permissionItem.Permission = mapPermissionStringToInt64(permission["permission"].(string))
+		permissionList.Items = append(permissionList.Items, &permissionItem)
+	}
+	return &permissionList
+}
+
+// permissionsToResourceAssignments converts the `permissions` blocks of the folder and dashboard permission resources
+// to the assignments expected by the access control API.
+func permissionsToResourceAssignments(permissions []interface{}) []resourcePermissionAssignment {
+	assignments := make([]resourcePermissionAssignment, 0, len(permissions))
+	for _, permission := range permissions {
+		permission := permission.(map[string]interface{})
+		assignment := resourcePermissionAssignment{
+			BuiltInRole: permission["role"].(string),
+			TeamID:      int64(permission["team_id"].(int)),
+			UserID:      int64(permission["user_id"].(int)),
+			Permission:  permission["permission"].(string),
+		}
+		if permission["service_account_id"].(int) > 0 {
+			assignment.UserID = int64(permission["service_account_id"].(int))
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments
+}
+
+// resourcePermissionsToState converts the managed permissions returned by the access control API
+// to `permissions` blocks of the folder and dashboard permission resources.
+func resourcePermissionsToState(permissions []*resourcePermission) []interface{} {
+	permissionItems := []interface{}{}
+	for _, permission := range permissions {
+		if !permission.IsManaged || permission.IsInherited {
+			continue
+		}
+		permissionItem := map[string]interface{}{
+			"role":               permission.BuiltInRole,
+			"team_id":            permission.TeamID,
+			"user_id":            permission.UserID,
+			"service_account_id": 0,
+			"permission":         permission.Permission,
+		}
+		if permission.IsServiceAccount {
+			permissionItem["user_id"] = 0
+			permissionItem["service_account_id"] = permission.UserID
+		}
+		permissionItems = append(permissionItems, permissionItem)
+	}
+	return permissionItems
+}
+
+// legacyPermissionToState converts a permission returned by the legacy permissions API to a `permissions` block.
+// The legacy API doesn't differentiate users from service accounts, so the configured service accounts are used to tell them apart.
+func legacyPermissionToState(role string, teamID, userID, permission int64, serviceAccountIDs map[int64]bool) map[string]interface{} {
+	permissionItem := map[string]interface{}{
+		"role":               role,
+		"team_id":            teamID,
+		"user_id":            userID,
+		"service_account_id": 0,
+		"permission":         mapPermissionInt64ToString(permission),
+	}
+	if serviceAccountIDs[userID] {
+		permissionItem["user_id"] = 0
+		permissionItem["service_account_id"] = userID
+	}
+	return permissionItem
+}
+
+func configuredServiceAccountIDs(d *schema.ResourceData) map[int64]bool {
+	ids := map[int64]bool{}
+	for _, permission := range d.Get("permissions").(*schema.Set).List() {
+		if id := permission.(map[string]interface{})["service_account_id"].(int); id > 0 {
+			ids[int64(id)] = true
+		}
+	}
+	return ids
+}
+
 func mapPermissionStringToInt64(permission string) int64 {
 	permissionInt := int64(-1)
 	switch permission {
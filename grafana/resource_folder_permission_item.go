@@ -0,0 +1,33 @@
+package grafana
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var folderPermissionItem = &permissionItemResource{
+	resourceType: "folders",
+	uidAttribute: "folder_uid",
+	permissions:  []string{"View", "Edit", "Admin"},
+}
+
+func ResourceFolderPermissionItem() *schema.Resource {
+	return &schema.Resource{
+
+		Description: `
+Manages a single permission item for a folder. Conflicts with the [grafana_folder_permission](https://registry.terraform.io/providers/grafana/grafana/latest/docs/resources/folder_permission) resource which manages the entire set of permissions for a folder.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/roles-and-permissions/access-control/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/folder_permissions/)
+
+This resource requires Grafana 9.0.0 or later.
+`,
+
+		CreateContext: folderPermissionItem.create,
+		ReadContext:   folderPermissionItem.read,
+		UpdateContext: folderPermissionItem.update,
+		DeleteContext: folderPermissionItem.delete,
+		Importer:      folderPermissionItem.importer(),
+
+		Schema: folderPermissionItem.schema("The UID of the folder."),
+	}
+}
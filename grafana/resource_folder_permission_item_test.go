@@ -0,0 +1,49 @@
+package grafana
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccFolderPermissionItem_basic(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=9.0.0")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccPermissionItemCheckDestroy("folders"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_folder_permission_item/resource.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccPermissionItemCheckExists("grafana_folder_permission_item.on_role", "folders"),
+					testAccPermissionItemCheckExists("grafana_folder_permission_item.on_team", "folders"),
+					testAccPermissionItemCheckExists("grafana_folder_permission_item.on_user", "folders"),
+					testAccPermissionItemCheckExists("grafana_folder_permission_item.on_service_account", "folders"),
+				),
+			},
+			{
+				ResourceName:      "grafana_folder_permission_item.on_team",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "grafana_folder_permission_item.on_service_account",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Changing one item's permission doesn't affect the others
+			{
+				Config: testAccExampleWithReplace(t, "resources/grafana_folder_permission_item/resource.tf", map[string]string{
+					`permission = "Admin"`: `permission = "View"`,
+				}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_folder_permission_item.on_user", "permission", "View"),
+					testAccPermissionItemCheckExists("grafana_folder_permission_item.on_user", "folders"),
+					testAccPermissionItemCheckExists("grafana_folder_permission_item.on_team", "folders"),
+				),
+			},
+		},
+	})
+}
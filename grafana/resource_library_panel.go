@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
 )
@@ -26,6 +27,7 @@ Manages Grafana library panels.
 		ReadContext:   ReadLibraryPanel,
 		UpdateContext: UpdateLibraryPanel,
 		DeleteContext: DeleteLibraryPanel,
+		CustomizeDiff: customizeLibraryPanelDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -50,9 +52,11 @@ Manages Grafana library panels.
 				Description: "The numeric ID of the library panel computed by Grafana.",
 			},
 			"folder_id": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Description: "ID of the folder where the library panel is stored.",
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"folder_uid"},
+				Description:   "ID of the folder where the library panel is stored. Set to `0` for the General folder.",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -70,12 +74,14 @@ Manages Grafana library panels.
 				Description: "Type of the library panel (eg. text).",
 			},
 			"model_json": {
-				Type:         schema.TypeString,
-				Required:     true,
-				StateFunc:    normalizeLibraryPanelModelJSON,
-				ValidateFunc: validateLibraryPanelModelJSON,
-				Description:  "The JSON model for the library panel.",
+				Type:             schema.TypeString,
+				Required:         true,
+				StateFunc:        normalizeLibraryPanelModelJSON,
+				ValidateFunc:     validateLibraryPanelModelJSON,
+				DiffSuppressFunc: suppressJSONModelDiff(normalizeLibraryPanelModelJSON, removeIgnoredJSONPaths),
+				Description:      "The JSON model for the library panel.",
 			},
+			"ignore_paths": ignorePathsSchema("library panel model"),
 			"version": {
 				Type:        schema.TypeInt,
 				Computed:    true,
@@ -87,9 +93,20 @@ Manages Grafana library panels.
 				Description: "Name of the folder containing the library panel.",
 			},
 			"folder_uid": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Unique ID (UID) of the folder containing the library panel.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"folder_id"},
+				Description:   "Unique ID (UID) of the folder containing the library panel. This can be set instead of `folder_id`.",
+			},
+			"on_delete": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "fail",
+				ValidateFunc: validation.StringInSlice([]string{"fail", "unlink"}, false),
+				Description: "What to do when the library panel is deleted while dashboards use it. " +
+					"With `fail`, the deletion fails and lists the dashboards. " +
+					"With `unlink`, the library panel is replaced by a copy of its model in the dashboards before it is deleted.",
 			},
 			"created": {
 				Type:        schema.TypeString,
@@ -113,7 +130,10 @@ Manages Grafana library panels.
 
 func CreateLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*client).gapi
-	panel := makeLibraryPanel(d)
+	panel, err := makeLibraryPanel(d, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	resp, err := client.NewLibraryPanel(panel)
 	if err != nil {
 		return diag.FromErr(err)
@@ -151,6 +171,7 @@ func ReadLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interfac
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	removeIgnoredJSONPaths(d, remotePanelJSON)
 	modelJSON := normalizeLibraryPanelModelJSON(remotePanelJSON)
 
 	d.SetId(panel.UID)
@@ -185,7 +206,18 @@ func ReadLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interfac
 func UpdateLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*client).gapi
 	uid := d.Id()
-	panel := makeLibraryPanel(d)
+	panel, err := makeLibraryPanel(d, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if ignorePaths := listToStringSlice(d.Get("ignore_paths").([]interface{})); len(ignorePaths) > 0 {
+		// Keep the values of the ignored paths that are in Grafana
+		remote, err := client.LibraryPanelByUID(uid)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		copyJSONPaths(remote.Model, panel.Model, ignorePaths)
+	}
 
 	resp, err := client.PatchLibraryPanel(uid, panel)
 	if err != nil {
@@ -199,14 +231,105 @@ func UpdateLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interf
 func DeleteLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*client).gapi
 	uid := d.Id()
-	_, err := client.DeleteLibraryPanel(uid)
+
+	connections, err := client.LibraryPanelConnections(uid)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "status: 404") {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	if len(*connections) > 0 {
+		dashboardIDs := make([]int64, 0, len(*connections))
+		for _, connection := range *connections {
+			dashboardIDs = append(dashboardIDs, connection.DashboardID)
+		}
+		dashboards, err := client.DashboardsByIDs(dashboardIDs)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if d.Get("on_delete").(string) != "unlink" {
+			titles := make([]string, 0, len(dashboards))
+			for _, dashboard := range dashboards {
+				titles = append(titles, fmt.Sprintf("%q (uid %s)", dashboard.Title, dashboard.UID))
+			}
+			return diag.Errorf("library panel %q is used by the following dashboards: %s. "+
+				"Remove it from these dashboards, or set `on_delete = \"unlink\"` to replace it by a copy of the panel in the dashboards",
+				d.Get("name").(string), strings.Join(titles, ", "))
+		}
+
+		panel, err := client.LibraryPanelByUID(uid)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, search := range dashboards {
+			dashboard, err := client.DashboardByUID(search.UID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if !unlinkLibraryPanel(dashboard.Model, panel) {
+				continue
+			}
+			if _, err := client.NewDashboard(gapi.Dashboard{
+				Model:     dashboard.Model,
+				FolderID:  dashboard.FolderID,
+				Overwrite: true,
+				Message:   fmt.Sprintf("Unlinked library panel %s", panel.Name),
+			}); err != nil {
+				return diag.Errorf("error unlinking library panel %q from dashboard %q: %s", panel.Name, search.Title, err)
+			}
+		}
+	}
+
+	_, err = client.DeleteLibraryPanel(uid)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func makeLibraryPanel(d *schema.ResourceData) gapi.LibraryPanel {
+// customizeLibraryPanelDiff marks the folder attribute that isn't configured as unknown when the other one changes.
+func customizeLibraryPanelDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.HasChange("folder_uid") {
+		return d.SetNewComputed("folder_id")
+	}
+	if d.HasChange("folder_id") {
+		return d.SetNewComputed("folder_uid")
+	}
+	return nil
+}
+
+// unlinkLibraryPanel replaces the references to the library panel in the dashboard by a copy of its model.
+// It returns whether the dashboard used the library panel.
+func unlinkLibraryPanel(dashboard map[string]interface{}, panel *gapi.LibraryPanel) bool {
+	unlinked := false
+	forEachDashboardPanel(dashboard["panels"], func(dashboardPanel map[string]interface{}) {
+		ref, _ := dashboardPanel["libraryPanel"].(map[string]interface{})
+		if uid, _ := ref["uid"].(string); uid != panel.UID {
+			return
+		}
+
+		// Keep the position and ID of the panel in the dashboard, like the "Unlink" action of Grafana
+		id, gridPos := dashboardPanel["id"], dashboardPanel["gridPos"]
+		for k := range dashboardPanel {
+			delete(dashboardPanel, k)
+		}
+		for k, v := range panel.Model {
+			dashboardPanel[k] = v
+		}
+		delete(dashboardPanel, "libraryPanel")
+		dashboardPanel["id"] = id
+		dashboardPanel["gridPos"] = gridPos
+		unlinked = true
+	})
+	return unlinked
+}
+
+func makeLibraryPanel(d *schema.ResourceData, client *gapi.Client) (gapi.LibraryPanel, error) {
 	modelJSON := d.Get("model_json").(string)
 	panelJSON, err := unmarshalLibraryPanelModelJSON(modelJSON)
 
@@ -217,9 +340,18 @@ func makeLibraryPanel(d *schema.ResourceData) gapi.LibraryPanel {
 		Model:  panelJSON,
 	}
 	if err != nil {
-		return panel
+		return panel, nil
+	}
+
+	// The library elements API takes folder IDs, so the folder UID is resolved
+	if folderUID, ok := d.GetOk("folder_uid"); ok && d.HasChange("folder_uid") {
+		folder, err := client.FolderByUID(folderUID.(string))
+		if err != nil {
+			return panel, fmt.Errorf("error getting folder %s: %s", folderUID, err)
+		}
+		panel.Folder = folder.ID
 	}
-	return panel
+	return panel, nil
 }
 
 // unmarshalLibraryPanelModelJSON is a convenience func for unmarshalling
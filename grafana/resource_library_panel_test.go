@@ -2,6 +2,8 @@ package grafana
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
 	"testing"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
@@ -121,6 +123,174 @@ func TestAccLibraryPanel_dashboard(t *testing.T) {
 	})
 }
 
+func TestAccLibraryPanel_folder_uid(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=8.0.0")
+
+	var panel gapi.LibraryPanel
+	var folder gapi.Folder
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccLibraryPanelFolderCheckDestroy(&panel, &folder),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_library_panel/_acc_folder_uid.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccLibraryPanelCheckExists("grafana_library_panel.test_folder", &panel),
+					testAccFolderCheckExists("grafana_folder.test_folder", &folder),
+					testAccLibraryPanelCheckExistsInFolder(&panel, &folder),
+					resource.TestCheckResourceAttrPair("grafana_library_panel.test_folder", "folder_uid", "grafana_folder.test_folder", "uid"),
+					resource.TestCheckResourceAttrPair("grafana_library_panel.test_folder", "folder_id", "grafana_folder.test_folder", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLibraryPanel_on_delete(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=8.0.0")
+
+	var panel gapi.LibraryPanel
+	var dashboard gapi.Dashboard
+
+	// The dashboard references the library panel by uid, so that it can outlive it
+	config := func(withPanel bool, onDelete string) string {
+		dependsOn := ""
+		panelConfig := ""
+		if withPanel {
+			dependsOn = "depends_on = [grafana_library_panel.test]"
+			panelConfig = fmt.Sprintf(`
+resource "grafana_library_panel" "test" {
+  uid       = "on-delete-panel"
+  name      = "on-delete"
+  on_delete = "%s"
+  model_json = jsonencode({
+    title = "on-delete"
+    type  = "text"
+    options = { content = "library panel content" }
+  })
+}`, onDelete)
+		}
+		return panelConfig + fmt.Sprintf(`
+resource "grafana_dashboard" "test" {
+  %s
+  config_json = jsonencode({
+    uid   = "on-delete-dashboard"
+    title = "Library panel on_delete"
+    panels = [{
+      id           = 3
+      gridPos      = { x = 0, y = 0, w = 12, h = 8 }
+      libraryPanel = { uid = "on-delete-panel", name = "on-delete" }
+    }]
+  })
+}`, dependsOn)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccLibraryPanelCheckDestroy(&panel),
+			testAccDashboardCheckDestroy(&dashboard),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: config(true, "fail"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccLibraryPanelCheckExists("grafana_library_panel.test", &panel),
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "dashboard_ids.#", "1"),
+				),
+			},
+			{
+				Config:      config(false, "fail"),
+				ExpectError: regexp.MustCompile(`library panel "on-delete" is used by the following dashboards: "Library panel on_delete" \(uid on-delete-dashboard\)`),
+			},
+			{
+				Config: config(true, "unlink"),
+				Check:  resource.TestCheckResourceAttr("grafana_library_panel.test", "on_delete", "unlink"),
+			},
+			{
+				// The dashboard no longer matches its configuration once the panel is unlinked
+				Config:             config(false, "unlink"),
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeTestCheckFunc(
+					testAccLibraryPanelCheckDestroy(&panel),
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					func(s *terraform.State) error {
+						panels, _ := dashboard.Model["panels"].([]interface{})
+						if len(panels) != 1 {
+							return fmt.Errorf("expected 1 panel, got %d", len(panels))
+						}
+						unlinked := panels[0].(map[string]interface{})
+						if _, ok := unlinked["libraryPanel"]; ok {
+							return fmt.Errorf("expected the library panel to be unlinked, got %v", unlinked)
+						}
+						if unlinked["type"] != "text" || unlinked["id"] != 3.0 || !reflect.DeepEqual(unlinked["options"], map[string]interface{}{"content": "library panel content"}) {
+							return fmt.Errorf("expected the panel to be a copy of the library panel, got %v", unlinked)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestUnlinkLibraryPanel(t *testing.T) {
+	IsUnitTest(t)
+
+	panel := &gapi.LibraryPanel{
+		UID: "shared",
+		Model: map[string]interface{}{
+			"id":      1.0,
+			"type":    "text",
+			"title":   "Shared",
+			"gridPos": map[string]interface{}{"x": 0.0, "y": 0.0, "w": 6.0, "h": 3.0},
+			"libraryPanel": map[string]interface{}{
+				"uid": "shared",
+			},
+		},
+	}
+	dashboard := map[string]interface{}{
+		"panels": []interface{}{
+			map[string]interface{}{"id": 1.0, "type": "graph"},
+			map[string]interface{}{"id": 2.0, "type": "row", "collapsed": true, "panels": []interface{}{
+				map[string]interface{}{
+					"id":           3.0,
+					"gridPos":      map[string]interface{}{"x": 12.0, "y": 9.0, "w": 12.0, "h": 8.0},
+					"libraryPanel": map[string]interface{}{"uid": "shared", "name": "Shared"},
+				},
+			}},
+		},
+	}
+
+	if !unlinkLibraryPanel(dashboard, panel) {
+		t.Fatal("expected the dashboard to use the library panel")
+	}
+	expected := map[string]interface{}{
+		"panels": []interface{}{
+			map[string]interface{}{"id": 1.0, "type": "graph"},
+			map[string]interface{}{"id": 2.0, "type": "row", "collapsed": true, "panels": []interface{}{
+				map[string]interface{}{
+					"id":      3.0,
+					"type":    "text",
+					"title":   "Shared",
+					"gridPos": map[string]interface{}{"x": 12.0, "y": 9.0, "w": 12.0, "h": 8.0},
+				},
+			}},
+		},
+	}
+	if !reflect.DeepEqual(dashboard, expected) {
+		t.Errorf("unexpected dashboard:\ngot:  %v\nwant: %v", dashboard, expected)
+	}
+
+	if unlinkLibraryPanel(dashboard, panel) {
+		t.Error("expected the dashboard to no longer use the library panel")
+	}
+}
+
 func testAccLibraryPanelCheckExists(rn string, panel *gapi.LibraryPanel) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -0,0 +1,225 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// This file contains the shared implementation of the `*_permission_item` resources.
+// Each of them manages a single grant on a resource through the access control API (Grafana 9+),
+// leaving the grants of other principals untouched.
+
+const (
+	permissionItemUser           = "user"
+	permissionItemTeam           = "team"
+	permissionItemServiceAccount = "service_account"
+	permissionItemRole           = "role"
+
+	permissionItemIDSeparator = ":"
+)
+
+type permissionItemResource struct {
+	// resourceType is the access control resource type (`folders`, `dashboards` or `datasources`)
+	resourceType string
+	// uidAttribute is the attribute that holds the UID of the resource the permission applies to
+	uidAttribute string
+	// permissions are the permission levels that can be granted on the resource
+	permissions []string
+}
+
+func (r *permissionItemResource) schema(uidDescription string) map[string]*schema.Schema {
+	principals := []string{"user_id", "team_id", "service_account_id", "role"}
+	return map[string]*schema.Schema{
+		r.uidAttribute: {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: uidDescription,
+		},
+		"user_id": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: principals,
+			Description:  "ID of the user to manage permissions for.",
+		},
+		"team_id": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: principals,
+			Description:  "ID of the team to manage permissions for.",
+		},
+		"service_account_id": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: principals,
+			Description:  "ID of the service account to manage permissions for.",
+		},
+		"role": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: principals,
+			ValidateFunc: validation.StringInSlice([]string{"Viewer", "Editor", "Admin"}, false),
+			Description:  "Manage permissions for the `Viewer`, `Editor` or `Admin` built-in roles.",
+		},
+		"permission": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(r.permissions, false),
+			Description:  allowedValuesDescription("Permission to associate with item", r.permissions),
+		},
+	}
+}
+
+func (r *permissionItemResource) importer() *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			uid, kind, identifier, err := unpackPermissionItemID(d.Id())
+			if err != nil {
+				return nil, err
+			}
+			d.Set(r.uidAttribute, uid)
+			switch kind {
+			case permissionItemRole:
+				d.Set("role", identifier)
+			default:
+				id, err := strconv.ParseInt(identifier, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s ID %q: %w", kind, identifier, err)
+				}
+				d.Set(kind+"_id", id)
+			}
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+}
+
+func (r *permissionItemResource) create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	uid := d.Get(r.uidAttribute).(string)
+	kind, identifier := permissionItemPrincipal(d)
+	d.SetId(packPermissionItemID(uid, kind, identifier))
+
+	return r.update(ctx, d, meta)
+}
+
+func (r *permissionItemResource) update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	uid, kind, identifier, err := unpackPermissionItemID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := client.setResourcePermission(r.resourceType, uid, permissionItemPath(kind, identifier), d.Get("permission").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return r.read(ctx, d, meta)
+}
+
+func (r *permissionItemResource) read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	uid, kind, identifier, err := unpackPermissionItemID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	permissions, err := client.listResourcePermissions(r.resourceType, uid)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "status: 404") {
+			log.Printf("[WARN] removing permission item %s from state because the %s no longer exists in grafana", d.Id(), r.resourceType)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	for _, permission := range permissions {
+		if !permission.IsManaged || permission.IsInherited || !permissionItemMatches(permission, kind, identifier) {
+			continue
+		}
+		d.Set("permission", permission.Permission)
+		return nil
+	}
+
+	log.Printf("[WARN] removing permission item %s from state because it no longer exists in grafana", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func (r *permissionItemResource) delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	uid, kind, identifier, err := unpackPermissionItemID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := client.setResourcePermission(r.resourceType, uid, permissionItemPath(kind, identifier), ""); err != nil {
+		if strings.HasPrefix(err.Error(), "status: 404") {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func permissionItemPrincipal(d *schema.ResourceData) (string, string) {
+	for _, kind := range []string{permissionItemUser, permissionItemTeam, permissionItemServiceAccount} {
+		if id, ok := d.GetOk(kind + "_id"); ok {
+			return kind, strconv.Itoa(id.(int))
+		}
+	}
+	return permissionItemRole, d.Get("role").(string)
+}
+
+func permissionItemPath(kind, identifier string) string {
+	switch kind {
+	case permissionItemTeam:
+		return "teams/" + identifier
+	case permissionItemRole:
+		return "builtInRoles/" + identifier
+	default:
+		// Service accounts are users as far as permissions are concerned
+		return "users/" + identifier
+	}
+}
+
+func permissionItemMatches(permission *resourcePermission, kind, identifier string) bool {
+	switch kind {
+	case permissionItemTeam:
+		return strconv.FormatInt(permission.TeamID, 10) == identifier
+	case permissionItemRole:
+		return permission.BuiltInRole == identifier
+	default:
+		return permission.UserID != 0 && strconv.FormatInt(permission.UserID, 10) == identifier
+	}
+}
+
+func packPermissionItemID(uid, kind, identifier string) string {
+	return strings.Join([]string{uid, kind, identifier}, permissionItemIDSeparator)
+}
+
+func unpackPermissionItemID(id string) (string, string, string, error) {
+	parts := strings.Split(id, permissionItemIDSeparator)
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("invalid permission item ID %q, expected `<uid>:<type>:<identifier>`", id)
+	}
+	switch parts[1] {
+	case permissionItemUser, permissionItemTeam, permissionItemServiceAccount, permissionItemRole:
+		return parts[0], parts[1], parts[2], nil
+	}
+	return "", "", "", fmt.Errorf("invalid permission item type %q in ID %q, expected one of `user`, `team`, `service_account` or `role`", parts[1], id)
+}
@@ -0,0 +1,102 @@
+package grafana
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestUnpackPermissionItemID(t *testing.T) {
+	IsUnitTest(t)
+
+	for _, tc := range []struct {
+		id         string
+		uid        string
+		kind       string
+		identifier string
+		wantErr    bool
+	}{
+		{id: "abc:user:1", uid: "abc", kind: "user", identifier: "1"},
+		{id: "abc:service_account:12", uid: "abc", kind: "service_account", identifier: "12"},
+		{id: "abc:team:3", uid: "abc", kind: "team", identifier: "3"},
+		{id: "abc:role:Viewer", uid: "abc", kind: "role", identifier: "Viewer"},
+		{id: "abc:group:1", wantErr: true},
+		{id: "abc:user", wantErr: true},
+		{id: ":user:1", wantErr: true},
+	} {
+		t.Run(tc.id, func(t *testing.T) {
+			uid, kind, identifier, err := unpackPermissionItemID(tc.id)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for %q", tc.id)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if uid != tc.uid || kind != tc.kind || identifier != tc.identifier {
+				t.Errorf("got (%q, %q, %q), expected (%q, %q, %q)", uid, kind, identifier, tc.uid, tc.kind, tc.identifier)
+			}
+			if packed := packPermissionItemID(uid, kind, identifier); packed != tc.id {
+				t.Errorf("got %q when packing, expected %q", packed, tc.id)
+			}
+		})
+	}
+}
+
+func testAccPermissionItemCheckExists(rn string, resourceType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s\n %#v", rn, s.RootModule().Resources)
+		}
+
+		uid, kind, identifier, err := unpackPermissionItemID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*client)
+		permissions, err := client.listResourcePermissions(resourceType, uid)
+		if err != nil {
+			return fmt.Errorf("error getting %s permissions: %s", resourceType, err)
+		}
+		for _, permission := range permissions {
+			if permission.IsManaged && !permission.IsInherited && permissionItemMatches(permission, kind, identifier) {
+				if permission.Permission != rs.Primary.Attributes["permission"] {
+					return fmt.Errorf("expected permission %s for %s, got %s", rs.Primary.Attributes["permission"], rn, permission.Permission)
+				}
+				return nil
+			}
+		}
+
+		return fmt.Errorf("permission item %s not found", rs.Primary.ID)
+	}
+}
+
+func testAccPermissionItemCheckDestroy(resourceType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*client)
+		for _, rs := range s.RootModule().Resources {
+			uid, kind, identifier, err := unpackPermissionItemID(rs.Primary.ID)
+			if err != nil {
+				// Not a permission item
+				continue
+			}
+			permissions, err := client.listResourcePermissions(resourceType, uid)
+			if err != nil {
+				// The parent resource was destroyed as well
+				continue
+			}
+			for _, permission := range permissions {
+				if permission.IsManaged && !permission.IsInherited && permissionItemMatches(permission, kind, identifier) {
+					return fmt.Errorf("permission item %s still exists", rs.Primary.ID)
+				}
+			}
+		}
+		return nil
+	}
+}
@@ -1,10 +1,12 @@
 package grafana
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -35,6 +37,7 @@ func cloneResourceSchemaForDatasource(r *schema.Resource, updates map[string]*sc
 		clone[k].DiffSuppressFunc = nil
 		clone[k].ValidateDiagFunc = nil
 		clone[k].ValidateFunc = nil
+		clone[k].ConflictsWith = nil
 	}
 	for k, v := range updates {
 		if v == nil {
@@ -46,6 +49,43 @@ func cloneResourceSchemaForDatasource(r *schema.Resource, updates map[string]*sc
 	return clone
 }
 
+// computedSchemaForDatasource returns a copy of a resource schema, including its nested blocks, where all the attributes are computed.
+func computedSchemaForDatasource(resourceSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	clone := make(map[string]*schema.Schema, len(resourceSchema))
+	for k, v := range resourceSchema {
+		computed := &schema.Schema{
+			Type:        v.Type,
+			Computed:    true,
+			Description: v.Description,
+			Sensitive:   v.Sensitive,
+			Elem:        v.Elem,
+		}
+		if elem, ok := v.Elem.(*schema.Resource); ok {
+			computed.Elem = &schema.Resource{Schema: computedSchemaForDatasource(elem.Schema)}
+		}
+		clone[k] = computed
+	}
+	return clone
+}
+
 func allowedValuesDescription(description string, allowedValues []string) string {
 	return fmt.Sprintf("%s. Allowed values: `%s`.", description, strings.Join(allowedValues, "`, `"))
 }
+
+// disableProvenanceSchema is the `disable_provenance` attribute of the alerting provisioning resources.
+func disableProvenanceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "Set to true to keep the resource editable in the Grafana UI. By default, resources provisioned by Terraform can only be changed through the API. " +
+			"Grafana doesn't allow removing this protection from an existing resource, so setting this attribute to true recreates the resource. " +
+			"Setting it to false updates the resource in place, including resources that were imported from the Grafana UI.",
+	}
+}
+
+// forceNewOnDisableProvenance is the CustomizeDiffFunc of `disable_provenance`. Only removing the provenance of a resource requires
+// to recreate it, Grafana adds it on update. Resources created in the Grafana UI are read without provenance, so they are updated in place.
+var forceNewOnDisableProvenance = customdiff.ForceNewIfChange("disable_provenance", func(ctx context.Context, old, new, meta interface{}) bool {
+	return !old.(bool) && new.(bool)
+})